@@ -20,7 +20,10 @@ var globalFlags = []cli.Flag{}
 // registerCmd registers a cli command
 func registerCmd(cmd cli.Command) {
 	commands = append(commands, cmd)
-	commandsTree.Insert(cmd.Name)
+	// Hidden commands are never suggested.
+	if !cmd.Hidden {
+		commandsTree.Insert(cmd.Name)
+	}
 }
 
 // findClosestCommands to match a given string with commands trie tree.
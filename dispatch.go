@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Lambdas started with this environment variable set to "1" do not
+// listen on their own, instead they read a NotificationInfo per line
+// of JSON on stdin, invoke their handler and write back a
+// dispatchResponse per line of JSON on stdout.
+const dispatchEnv = "MINL_DISPATCH"
+
+// dispatchResponse is written back by the lambda for every
+// NotificationInfo it was sent.
+type dispatchResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// Maximum delay between restarts of a failing handler process.
+const maxHandlerRestartDelay = 30 * time.Second
+
+// Number of times a notification is delivered again after the handler
+// process died delivering it, unless configured otherwise.
+const defaultMaxRetries = 3
+
+// Time a handler has to respond to a notification before it is killed,
+// unless configured otherwise.
+const defaultDeliveryTimeout = 5 * time.Minute
+
+// Time a handler has to exit once its stdin is closed.
+const handlerStopTimeout = 10 * time.Second
+
+// handlerProcess is a running lambda speaking the dispatch protocol.
+type handlerProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Scanner
+}
+
+// startHandlerProcess starts the lambda command in dispatch mode.
+func startHandlerProcess(cmd *exec.Cmd) (*handlerProcess, error) {
+	return startHandlerProcessWith(cmd, cmd.Start)
+}
+
+// startHandlerProcessWith is startHandlerProcess starting the command
+// with start, which must be equivalent to cmd.Start.
+func startHandlerProcessWith(cmd *exec.Cmd, start func() error) (*handlerProcess, error) {
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, dispatchEnv+"=1")
+	// Handlers log to stderr, stdout carries responses.
+	cmd.Stderr = os.Stderr
+	// Keep terminal signals away from handlers, they are stopped by
+	// closing their stdin.
+	setProcessGroup(cmd)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = start(); err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return &handlerProcess{cmd: cmd, stdin: stdin, stdout: scanner}, nil
+}
+
+// errHandlerExited is returned when the handler process went away
+// while an event was being delivered.
+var errHandlerExited = errors.New("handler process exited")
+
+// errDeliveryCanceled is returned when doneCh got closed while an event
+// was being delivered.
+var errDeliveryCanceled = errors.New("delivery canceled")
+
+// undeliverableError is returned for a notification the handler process
+// died on every time it was delivered, so that it does not block the
+// notifications queued after it.
+type undeliverableError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *undeliverableError) Error() string {
+	return fmt.Sprintf("handler process died on all %d deliveries, giving up. %s", e.Attempts, e.Err)
+}
+
+// deliver sends a notification and waits for the handler's response at
+// most timeout, 0 waits forever, or until doneCh is closed. A non nil
+// error means the process is no longer usable.
+func (p *handlerProcess) deliver(notificationInfo NotificationInfo, timeout time.Duration, doneCh <-chan struct{}) (dispatchResponse, error) {
+	type result struct {
+		resp dispatchResponse
+		err  error
+	}
+	// Buffered, the exchange ends once the process is killed.
+	resultCh := make(chan result, 1)
+	go func() {
+		resp, err := p.exchange(notificationInfo)
+		resultCh <- result{resp, err}
+	}()
+
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+	select {
+	case r := <-resultCh:
+		return r.resp, r.err
+	case <-timeoutCh:
+		return dispatchResponse{}, fmt.Errorf("no response within %s", timeout)
+	case <-doneCh:
+		return dispatchResponse{}, errDeliveryCanceled
+	}
+}
+
+// exchange writes a notification and reads back the response.
+func (p *handlerProcess) exchange(notificationInfo NotificationInfo) (dispatchResponse, error) {
+	var resp dispatchResponse
+	data, err := json.Marshal(notificationInfo)
+	if err != nil {
+		return resp, err
+	}
+	if _, err = p.stdin.Write(append(data, '\n')); err != nil {
+		return resp, errHandlerExited
+	}
+	if !p.stdout.Scan() {
+		if err = p.stdout.Err(); err != nil {
+			return resp, err
+		}
+		return resp, errHandlerExited
+	}
+	if err = json.Unmarshal(p.stdout.Bytes(), &resp); err != nil {
+		return resp, fmt.Errorf("invalid response from handler: %s", err)
+	}
+	return resp, nil
+}
+
+// stop closes stdin, letting the handler exit cleanly, and reaps it.
+// Handlers still running after handlerStopTimeout are killed.
+func (p *handlerProcess) stop() error {
+	p.stdin.Close()
+	exitCh := make(chan error, 1)
+	go func() {
+		exitCh <- p.cmd.Wait()
+	}()
+	select {
+	case err := <-exitCh:
+		return err
+	case <-time.After(handlerStopTimeout):
+		killProcessGroup(p.cmd)
+		return <-exitCh
+	}
+}
+
+// kill terminates the handler and the processes it started right away.
+func (p *handlerProcess) kill() {
+	killProcessGroup(p.cmd)
+	p.cmd.Wait()
+}
+
+// lambdaHandler delivers notifications to a lambda one at a time,
+// restarting its process whenever it fails.
+type lambdaHandler struct {
+	lmeta      LambdaMetadata
+	newCmd     func() (*exec.Cmd, error)
+	queueCh    chan NotificationInfo
+	proc       *handlerProcess
+	delay      time.Duration
+	maxRetries int
+	timeout    time.Duration
+	journal    *journal // records undeliverable notifications, nil when not recording.
+}
+
+func newLambdaHandler(lmeta LambdaMetadata, newCmd func() (*exec.Cmd, error)) *lambdaHandler {
+	return &lambdaHandler{
+		lmeta:      lmeta,
+		newCmd:     newCmd,
+		queueCh:    make(chan NotificationInfo, 100),
+		delay:      time.Second,
+		maxRetries: defaultMaxRetries,
+		timeout:    defaultDeliveryTimeout,
+	}
+}
+
+// process starts the handler process unless it is already running,
+// failed starts are retried with a backoff.
+func (h *lambdaHandler) process(doneCh <-chan struct{}) (*handlerProcess, error) {
+	for h.proc == nil {
+		cmd, err := h.newCmd()
+		if err != nil {
+			return nil, err
+		}
+		if h.proc, err = startHandlerProcess(cmd); err == nil {
+			break
+		}
+		fmt.Printf("Unable to start lambda %s, retrying in %s. %s\n", h.lmeta.PackageName, h.delay, err)
+		if !h.backoff(doneCh) {
+			return nil, err
+		}
+	}
+	return h.proc, nil
+}
+
+// backoff waits before the next restart, returns false if doneCh
+// got closed meanwhile.
+func (h *lambdaHandler) backoff(doneCh <-chan struct{}) bool {
+	select {
+	case <-doneCh:
+		return false
+	case <-time.After(h.delay):
+	}
+	if h.delay *= 2; h.delay > maxHandlerRestartDelay {
+		h.delay = maxHandlerRestartDelay
+	}
+	return true
+}
+
+// dispatch delivers a notification, if the process dies or times out
+// during delivery it is restarted and the notification is delivered
+// again, at most maxRetries times before an *undeliverableError is
+// returned.
+func (h *lambdaHandler) dispatch(notificationInfo NotificationInfo, doneCh <-chan struct{}) (dispatchResponse, error) {
+	for attempt := 1; ; attempt++ {
+		proc, err := h.process(doneCh)
+		if err != nil {
+			return dispatchResponse{}, err
+		}
+		resp, err := proc.deliver(notificationInfo, h.timeout, doneCh)
+		if err == nil {
+			h.delay = time.Second
+			return resp, nil
+		}
+		proc.kill()
+		h.proc = nil
+		if err == errDeliveryCanceled {
+			return dispatchResponse{}, err
+		}
+		if attempt > h.maxRetries {
+			fmt.Printf("Lambda %s %s. %s\n", h.lmeta.PackageName, exitReason(proc.cmd.ProcessState), err)
+			return dispatchResponse{}, &undeliverableError{Attempts: attempt, Err: err}
+		}
+		fmt.Printf("Lambda %s %s, restarting in %s. %s\n", h.lmeta.PackageName, exitReason(proc.cmd.ProcessState), h.delay, err)
+		if !h.backoff(doneCh) {
+			return dispatchResponse{}, err
+		}
+	}
+}
+
+// run delivers queued notifications until doneCh is closed.
+func (h *lambdaHandler) run(doneCh <-chan struct{}) {
+	defer func() {
+		if h.proc != nil {
+			h.proc.stop()
+		}
+	}()
+	for {
+		select {
+		case <-doneCh:
+			return
+		case notificationInfo := <-h.queueCh:
+			resp, err := h.dispatch(notificationInfo, doneCh)
+			if err == errDeliveryCanceled {
+				return
+			} else if err != nil {
+				fmt.Printf("Unable to dispatch to lambda %s. %s\n", h.lmeta.PackageName, err)
+				if _, ok := err.(*undeliverableError); ok && h.journal != nil {
+					if err = h.journal.recordFailure(h.lmeta.PackageName, notificationInfo, err); err != nil {
+						fmt.Println("Unable to record failed notification.", err)
+					}
+				}
+			} else if resp.Error != "" {
+				fmt.Printf("Lambda %s failed. %s\n", h.lmeta.PackageName, resp.Error)
+			}
+		}
+	}
+}
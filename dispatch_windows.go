@@ -0,0 +1,13 @@
+package main
+
+import (
+	"os/exec"
+)
+
+// setProcessGroup does nothing, process groups are a unix concept.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the process of cmd.
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
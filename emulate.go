@@ -0,0 +1,674 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/cli"
+)
+
+// Emulate an S3 server.
+var emulateCmd = cli.Command{
+	Name:   "emulate",
+	Usage:  "Emulate an S3 server sending bucket notifications",
+	Action: mainEmulate,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "address",
+			Usage: "Address to listen on.",
+			Value: "127.0.0.1:9000",
+		},
+		cli.StringFlag{
+			Name:  "region",
+			Usage: "Region reported to clients.",
+			Value: "us-east-1",
+		},
+		cli.StringSliceFlag{
+			Name:  "bucket",
+			Usage: "Bucket to create on startup, may be repeated.",
+		},
+	},
+	CustomHelpTemplate: `NAME:
+   minl {{.Name}} - {{.Usage}}
+
+USAGE:
+   minl {{.Name}} [FLAGS]
+
+FLAGS:
+  {{range .Flags}}{{.}}
+  {{end}}
+DESCRIPTION:
+   Objects are kept in memory and requests are never authenticated, any
+   credentials are accepted. Supported are bucket creation, object PUT,
+   GET, HEAD, DELETE and copy, bucket notification configuration and
+   listening on bucket notifications.
+
+`,
+}
+
+// Maximum notifications queued for a listener, later ones are dropped
+// like a real server does for clients not keeping up.
+const emulatorListenerQueue = 1000
+
+// Interval at which listeners are sent whitespace to keep their
+// connection alive.
+const emulatorKeepAlive = 5 * time.Second
+
+// emulatedObject is an object stored by the emulator.
+type emulatedObject struct {
+	data         []byte
+	etag         string
+	contentType  string
+	userMetadata map[string]string
+	modTime      time.Time
+}
+
+// emulatedBucket is a bucket stored by the emulator.
+type emulatedBucket struct {
+	created      time.Time
+	objects      map[string]*emulatedObject
+	notification []byte // as set by the client.
+}
+
+// emulatorListener is a client listening on bucket notifications.
+type emulatorListener struct {
+	trigger Trigger
+	eventCh chan NotificationEvent
+}
+
+// emulator is an in-memory stand-in of an S3 server, good enough to
+// exercise lambdas without a real server.
+type emulator struct {
+	region string
+
+	mu        sync.Mutex
+	buckets   map[string]*emulatedBucket
+	listeners map[*emulatorListener]bool
+	sequence  uint64
+}
+
+func newEmulator(region string) *emulator {
+	return &emulator{
+		region:    region,
+		buckets:   make(map[string]*emulatedBucket),
+		listeners: make(map[*emulatorListener]bool),
+	}
+}
+
+// s3Error is the error response of the S3 API.
+type s3Error struct {
+	XMLName    xml.Name `xml:"Error"`
+	Code       string   `xml:"Code"`
+	Message    string   `xml:"Message"`
+	BucketName string   `xml:"BucketName,omitempty"`
+	Key        string   `xml:"Key,omitempty"`
+	Resource   string   `xml:"Resource"`
+	statusCode int
+}
+
+var (
+	errNoSuchBucket            = s3Error{Code: "NoSuchBucket", Message: "The specified bucket does not exist", statusCode: http.StatusNotFound}
+	errNoSuchKey               = s3Error{Code: "NoSuchKey", Message: "The specified key does not exist.", statusCode: http.StatusNotFound}
+	errBucketAlreadyOwnedByYou = s3Error{Code: "BucketAlreadyOwnedByYou", Message: "Your previous request to create the named bucket succeeded and you already own it.", statusCode: http.StatusConflict}
+	errInvalidBucketName       = s3Error{Code: "InvalidBucketName", Message: "The specified bucket is not valid.", statusCode: http.StatusBadRequest}
+	errIncompleteBody          = s3Error{Code: "IncompleteBody", Message: "You did not provide the number of bytes specified by the Content-Length HTTP header.", statusCode: http.StatusBadRequest}
+	errNotImplemented          = s3Error{Code: "NotImplemented", Message: "A header you provided implies functionality that is not implemented", statusCode: http.StatusNotImplemented}
+	errMethodNotAllowed        = s3Error{Code: "MethodNotAllowed", Message: "The specified method is not allowed against this resource.", statusCode: http.StatusMethodNotAllowed}
+)
+
+// writeError sends an S3 error response for the request.
+func writeError(w http.ResponseWriter, r *http.Request, s3Err s3Error, bucket, key string) {
+	s3Err.BucketName = bucket
+	s3Err.Key = key
+	s3Err.Resource = r.URL.Path
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(s3Err.statusCode)
+	// HEAD responses have no body.
+	if r.Method != http.MethodHead {
+		w.Write([]byte(xml.Header))
+		xml.NewEncoder(w).Encode(s3Err)
+	}
+}
+
+// writeXML sends a successful XML response.
+func writeXML(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.Write([]byte(xml.Header))
+	xml.NewEncoder(w).Encode(v)
+}
+
+// ServeHTTP routes path-style S3 requests.
+func (e *emulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Server", "minl-emulator")
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	var bucket, key string
+	if i := strings.Index(path, "/"); i >= 0 {
+		bucket, key = path[:i], path[i+1:]
+	} else {
+		bucket = path
+	}
+	query := r.URL.Query()
+
+	switch {
+	case bucket == "":
+		if r.Method != http.MethodGet {
+			writeError(w, r, errMethodNotAllowed, "", "")
+			return
+		}
+		e.listBuckets(w, r)
+	case key == "":
+		e.serveBucket(w, r, bucket, query)
+	default:
+		e.serveObject(w, r, bucket, key)
+	}
+}
+
+// serveBucket handles the requests on a bucket.
+func (e *emulator) serveBucket(w http.ResponseWriter, r *http.Request, bucket string, query url.Values) {
+	_, location := query["location"]
+	_, notification := query["notification"]
+	_, events := query["events"]
+	switch {
+	case r.Method == http.MethodPut && len(query) == 0:
+		e.makeBucket(w, r, bucket)
+	case r.Method == http.MethodHead && len(query) == 0:
+		if !e.bucketExists(bucket) {
+			writeError(w, r, errNoSuchBucket, bucket, "")
+		}
+	case r.Method == http.MethodGet && location:
+		if !e.bucketExists(bucket) {
+			writeError(w, r, errNoSuchBucket, bucket, "")
+			return
+		}
+		writeXML(w, struct {
+			XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LocationConstraint"`
+			Location string   `xml:",chardata"`
+		}{Location: e.region})
+	case notification:
+		e.serveBucketNotification(w, r, bucket)
+	case r.Method == http.MethodGet && events:
+		e.listenBucketNotification(w, r, bucket, query)
+	default:
+		writeError(w, r, errNotImplemented, bucket, "")
+	}
+}
+
+// serveObject handles the requests on an object.
+func (e *emulator) serveObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	if !plainObjectRequest(r.URL.Query()) {
+		// Multipart uploads, tagging, ACLs and the like.
+		writeError(w, r, errNotImplemented, bucket, key)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		if r.Header.Get("X-Amz-Copy-Source") != "" {
+			e.copyObject(w, r, bucket, key)
+		} else {
+			e.putObject(w, r, bucket, key)
+		}
+	case http.MethodGet, http.MethodHead:
+		e.getObject(w, r, bucket, key)
+	case http.MethodDelete:
+		e.deleteObject(w, r, bucket, key)
+	default:
+		writeError(w, r, errMethodNotAllowed, bucket, key)
+	}
+}
+
+// plainObjectRequest reports if an object request has no query other
+// than presigned request parameters.
+func plainObjectRequest(query url.Values) bool {
+	for name := range query {
+		if !strings.HasPrefix(name, "X-Amz-") {
+			return false
+		}
+	}
+	return true
+}
+
+// validBucketName is a relaxed check of S3 bucket naming rules.
+func validBucketName(bucket string) bool {
+	if len(bucket) < 3 || len(bucket) > 63 {
+		return false
+	}
+	for _, c := range bucket {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// createBucket adds an empty bucket, it is not an error if it exists.
+func (e *emulator) createBucket(bucket string) error {
+	if !validBucketName(bucket) {
+		return fmt.Errorf("invalid bucket name ‘%s’", bucket)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.buckets[bucket] == nil {
+		e.buckets[bucket] = &emulatedBucket{
+			created: time.Now().UTC(),
+			objects: make(map[string]*emulatedObject),
+		}
+	}
+	return nil
+}
+
+func (e *emulator) bucketExists(bucket string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.buckets[bucket] != nil
+}
+
+func (e *emulator) makeBucket(w http.ResponseWriter, r *http.Request, bucket string) {
+	if !validBucketName(bucket) {
+		writeError(w, r, errInvalidBucketName, bucket, "")
+		return
+	}
+	if e.bucketExists(bucket) {
+		writeError(w, r, errBucketAlreadyOwnedByYou, bucket, "")
+		return
+	}
+	e.createBucket(bucket)
+	w.Header().Set("Location", "/"+bucket)
+}
+
+func (e *emulator) listBuckets(w http.ResponseWriter, r *http.Request) {
+	type bucketInfo struct {
+		Name         string `xml:"Name"`
+		CreationDate string `xml:"CreationDate"`
+	}
+	var result struct {
+		XMLName xml.Name     `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListAllMyBucketsResult"`
+		Buckets []bucketInfo `xml:"Buckets>Bucket"`
+	}
+	e.mu.Lock()
+	for name, b := range e.buckets {
+		result.Buckets = append(result.Buckets, bucketInfo{name, b.created.Format(time.RFC3339)})
+	}
+	e.mu.Unlock()
+	sort.Slice(result.Buckets, func(i, j int) bool {
+		return result.Buckets[i].Name < result.Buckets[j].Name
+	})
+	writeXML(w, result)
+}
+
+// serveBucketNotification stores and returns the notification
+// configuration, it is only kept for clients to read it back, every
+// listener gets the notifications it asks for regardless.
+func (e *emulator) serveBucketNotification(w http.ResponseWriter, r *http.Request, bucket string) {
+	e.mu.Lock()
+	b := e.buckets[bucket]
+	e.mu.Unlock()
+	if b == nil {
+		writeError(w, r, errNoSuchBucket, bucket, "")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		e.mu.Lock()
+		config := b.notification
+		e.mu.Unlock()
+		if config == nil {
+			writeXML(w, struct {
+				XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ NotificationConfiguration"`
+			}{})
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write(config)
+	case http.MethodPut:
+		config, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, r, errIncompleteBody, bucket, "")
+			return
+		}
+		e.mu.Lock()
+		b.notification = config
+		e.mu.Unlock()
+	default:
+		writeError(w, r, errMethodNotAllowed, bucket, "")
+	}
+}
+
+// listenBucketNotification streams a NotificationInfo per line of JSON
+// for every matching event until the client goes away.
+func (e *emulator) listenBucketNotification(w http.ResponseWriter, r *http.Request, bucket string, query url.Values) {
+	if !e.bucketExists(bucket) {
+		writeError(w, r, errNoSuchBucket, bucket, "")
+		return
+	}
+	events, err := parseEvents(query["events"])
+	if err != nil || len(events) == 0 {
+		writeError(w, r, s3Error{Code: "InvalidArgument", Message: fmt.Sprint("Invalid events. ", err), statusCode: http.StatusBadRequest}, bucket, "")
+		return
+	}
+	l := &emulatorListener{
+		trigger: Trigger{
+			Bucket: bucket,
+			Events: events,
+			Prefix: query.Get("prefix"),
+			Suffix: query.Get("suffix"),
+		},
+		eventCh: make(chan NotificationEvent, emulatorListenerQueue),
+	}
+	e.mu.Lock()
+	e.listeners[l] = true
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		delete(e.listeners, l)
+		e.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	flush := func() {
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	flush()
+
+	keepAlive := time.NewTicker(emulatorKeepAlive)
+	defer keepAlive.Stop()
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			// Like MinIO a single space is sent, clients skip it.
+			if _, err = w.Write([]byte(" ")); err != nil {
+				return
+			}
+		case event := <-l.eventCh:
+			if err = enc.Encode(NotificationInfo{Records: []NotificationEvent{event}}); err != nil {
+				return
+			}
+		}
+		flush()
+	}
+}
+
+// notify sends an event to every listener it matches.
+func (e *emulator) notify(r *http.Request, eventName, bucket, key string, obj *emulatedObject) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sequence++
+	event := e.newEvent(r, eventName, bucket, key, obj)
+	for l := range e.listeners {
+		if !matchTrigger(l.trigger, event) {
+			continue
+		}
+		select {
+		case l.eventCh <- event:
+		default:
+			fmt.Printf("Dropped %s event of %s/%s, listener is not keeping up.\n", eventName, bucket, key)
+		}
+	}
+}
+
+// newEvent builds a notification event the way MinIO does.
+func (e *emulator) newEvent(r *http.Request, eventName, bucket, key string, obj *emulatedObject) NotificationEvent {
+	host, port, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	object := ObjectMeta{
+		Key:       key,
+		Sequencer: fmt.Sprintf("%016X", e.sequence),
+	}
+	if obj != nil {
+		object.Size = int64(len(obj.data))
+		object.ETag = obj.etag
+		object.ContentType = obj.contentType
+		object.UserMetadata = obj.userMetadata
+	}
+	principalID := requestAccessKey(r)
+	event := newNotificationEvent(eventName, e.region, principalID, bucket, object)
+	event.RequestParameters = map[string]string{
+		"accessKey":       principalID,
+		"region":          e.region,
+		"sourceIPAddress": host,
+	}
+	event.ResponseElements = map[string]string{
+		"x-amz-request-id":        fmt.Sprintf("%X", e.sequence),
+		"x-minio-origin-endpoint": "http://" + r.Host,
+	}
+	event.Source = SourceInfo{
+		Host:      host,
+		Port:      port,
+		UserAgent: r.UserAgent(),
+	}
+	return event
+}
+
+// requestAccessKey returns the access key a request was signed with,
+// they are never verified.
+func requestAccessKey(r *http.Request) string {
+	credential := r.URL.Query().Get("X-Amz-Credential")
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if i := strings.Index(auth, "Credential="); i >= 0 {
+			credential = auth[i+len("Credential="):]
+		}
+	}
+	if i := strings.Index(credential, "/"); i >= 0 {
+		return credential[:i]
+	}
+	return credential
+}
+
+// readObjectBody reads a PUT request body, decoding the aws-chunked
+// encoding used by streaming signatures. Chunk signatures are not
+// verified.
+func readObjectBody(r *http.Request) ([]byte, error) {
+	if r.Header.Get("X-Amz-Content-Sha256") != "STREAMING-AWS4-HMAC-SHA256-PAYLOAD" {
+		return ioutil.ReadAll(r.Body)
+	}
+	var data bytes.Buffer
+	body := bufio.NewReader(r.Body)
+	for {
+		// Every chunk is "<hex size>;chunk-signature=<signature>\r\n<data>\r\n".
+		header, err := body.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		header = strings.TrimSpace(header)
+		if i := strings.Index(header, ";"); i >= 0 {
+			header = header[:i]
+		}
+		size, err := strconv.ParseInt(header, 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed chunk header: %s", err)
+		}
+		if size == 0 {
+			break
+		}
+		if _, err = io.CopyN(&data, body, size); err != nil {
+			return nil, err
+		}
+		if _, err = body.Discard(2); err != nil {
+			return nil, err
+		}
+	}
+	if decoded := r.Header.Get("X-Amz-Decoded-Content-Length"); decoded != "" && decoded != strconv.Itoa(data.Len()) {
+		return nil, errors.New("decoded content length mismatch")
+	}
+	return data.Bytes(), nil
+}
+
+// lookupBucket returns a bucket with the lock held, or sends an error.
+func (e *emulator) lookupBucket(w http.ResponseWriter, r *http.Request, bucket, key string) *emulatedBucket {
+	e.mu.Lock()
+	b := e.buckets[bucket]
+	if b == nil {
+		e.mu.Unlock()
+		writeError(w, r, errNoSuchBucket, bucket, key)
+	}
+	return b
+}
+
+func (e *emulator) putObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	data, err := readObjectBody(r)
+	if err != nil {
+		writeError(w, r, errIncompleteBody, bucket, key)
+		return
+	}
+	sum := md5.Sum(data)
+	obj := &emulatedObject{
+		data:         data,
+		etag:         hex.EncodeToString(sum[:]),
+		contentType:  r.Header.Get("Content-Type"),
+		userMetadata: make(map[string]string),
+		modTime:      time.Now().UTC(),
+	}
+	if obj.contentType == "" {
+		obj.contentType = "application/octet-stream"
+	}
+	for name, values := range r.Header {
+		if strings.HasPrefix(strings.ToLower(name), "x-amz-meta-") {
+			obj.userMetadata[name] = strings.Join(values, ",")
+		}
+	}
+	b := e.lookupBucket(w, r, bucket, key)
+	if b == nil {
+		return
+	}
+	b.objects[key] = obj
+	e.mu.Unlock()
+
+	w.Header().Set("ETag", `"`+obj.etag+`"`)
+	e.notify(r, "s3:ObjectCreated:Put", bucket, key, obj)
+}
+
+func (e *emulator) copyObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	source, err := url.PathUnescape(strings.TrimPrefix(r.Header.Get("X-Amz-Copy-Source"), "/"))
+	if err != nil {
+		writeError(w, r, errNoSuchKey, bucket, key)
+		return
+	}
+	// Source versions are not supported, there is only one.
+	if i := strings.Index(source, "?"); i >= 0 {
+		source = source[:i]
+	}
+	var srcBucket, srcKey string
+	if i := strings.Index(source, "/"); i >= 0 {
+		srcBucket, srcKey = source[:i], source[i+1:]
+	}
+
+	e.mu.Lock()
+	src := e.buckets[srcBucket]
+	if src == nil || src.objects[srcKey] == nil {
+		e.mu.Unlock()
+		if src == nil {
+			writeError(w, r, errNoSuchBucket, srcBucket, srcKey)
+		} else {
+			writeError(w, r, errNoSuchKey, srcBucket, srcKey)
+		}
+		return
+	}
+	obj := *src.objects[srcKey]
+	e.mu.Unlock()
+	obj.modTime = time.Now().UTC()
+
+	b := e.lookupBucket(w, r, bucket, key)
+	if b == nil {
+		return
+	}
+	b.objects[key] = &obj
+	e.mu.Unlock()
+
+	writeXML(w, struct {
+		XMLName      xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CopyObjectResult"`
+		LastModified string   `xml:"LastModified"`
+		ETag         string   `xml:"ETag"`
+	}{
+		LastModified: obj.modTime.Format(time.RFC3339),
+		ETag:         `"` + obj.etag + `"`,
+	})
+	e.notify(r, "s3:ObjectCreated:Copy", bucket, key, &obj)
+}
+
+func (e *emulator) getObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	b := e.lookupBucket(w, r, bucket, key)
+	if b == nil {
+		return
+	}
+	obj := b.objects[key]
+	e.mu.Unlock()
+	if obj == nil {
+		writeError(w, r, errNoSuchKey, bucket, key)
+		return
+	}
+
+	for name, value := range obj.userMetadata {
+		w.Header().Set(name, value)
+	}
+	w.Header().Set("ETag", `"`+obj.etag+`"`)
+	w.Header().Set("Content-Type", obj.contentType)
+	http.ServeContent(w, r, key, obj.modTime, bytes.NewReader(obj.data))
+	if r.Method == http.MethodHead {
+		e.notify(r, "s3:ObjectAccessed:Head", bucket, key, obj)
+	} else {
+		e.notify(r, "s3:ObjectAccessed:Get", bucket, key, obj)
+	}
+}
+
+func (e *emulator) deleteObject(w http.ResponseWriter, r *http.Request, bucket, key string) {
+	b := e.lookupBucket(w, r, bucket, key)
+	if b == nil {
+		return
+	}
+	obj := b.objects[key]
+	delete(b.objects, key)
+	e.mu.Unlock()
+
+	// Deleting a missing object succeeds, and notifies nothing.
+	w.WriteHeader(http.StatusNoContent)
+	if obj != nil {
+		e.notify(r, "s3:ObjectRemoved:Delete", bucket, key, nil)
+	}
+}
+
+func mainEmulate(ctx *cli.Context) {
+	e := newEmulator(ctx.String("region"))
+	for _, bucket := range ctx.StringSlice("bucket") {
+		if err := e.createBucket(bucket); err != nil {
+			fmt.Println("Unable to create bucket.", err)
+			os.Exit(1)
+		}
+	}
+
+	l, err := net.Listen("tcp", ctx.String("address"))
+	if err != nil {
+		fmt.Println("Unable to listen.", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Emulating S3 on http://%s, any credentials are accepted.\n", l.Addr())
+	fmt.Printf("   export S3_ENDPOINT=%s ACCESS_KEY=minio SECRET_KEY=minio123 S3_REGION=%s\n", l.Addr(), e.region)
+	if err = http.Serve(l, e); err != nil {
+		fmt.Println("Unable to serve.", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// eventCategory is a group of bucket notification event types sharing
+// a prefix, "<prefix>*" selects all of them.
+type eventCategory struct {
+	prefix string
+	names  []string
+}
+
+// Registry of the bucket notification event types lambdas can be
+// triggered by.
+var eventCategories = []eventCategory{
+	{"s3:ObjectCreated:", []string{"Put", "Post", "Copy", "CompleteMultipartUpload", "PutRetention", "PutLegalHold", "PutTagging", "DeleteTagging"}},
+	{"s3:ObjectAccessed:", []string{"Get", "Head", "GetRetention", "GetLegalHold"}},
+	{"s3:ObjectRemoved:", []string{"Delete", "DeleteMarkerCreated"}},
+	{"s3:Replication:", []string{"OperationFailedReplication", "OperationCompletedReplication", "OperationMissedThreshold", "OperationReplicatedAfterThreshold", "OperationNotTracked"}},
+	{"s3:LifecycleExpiration:", []string{"Delete", "DeleteMarkerCreated"}},
+	{"s3:ObjectTransition:", []string{"Failed", "Complete"}},
+	{"s3:ObjectRestore:", []string{"Post", "Completed"}},
+}
+
+// supportedEventTypes returns all the event types known to S3
+// servers, category wildcards included.
+func supportedEventTypes() []string {
+	var eventTypes []string
+	for _, category := range eventCategories {
+		eventTypes = append(eventTypes, category.prefix+"*")
+		for _, name := range category.names {
+			eventTypes = append(eventTypes, category.prefix+name)
+		}
+	}
+	return eventTypes
+}
+
+// isValidEventType reports if S3 servers accept the event type.
+func isValidEventType(eventType string) bool {
+	for _, supportedEventType := range supportedEventTypes() {
+		if supportedEventType == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// expandEventType returns the event types selected by a pattern,
+// which may end with a '*' wildcard. Wildcards of a whole category are
+// understood by S3 servers and are kept as they are, any other wildcard
+// is expanded into the event types it matches.
+func expandEventType(pattern string) []string {
+	if isValidEventType(pattern) {
+		return []string{pattern}
+	}
+	if !strings.HasSuffix(pattern, "*") {
+		return nil
+	}
+	var eventTypes []string
+	for _, category := range eventCategories {
+		// A pattern covering a whole category becomes its wildcard.
+		if matchEventType(pattern, category.prefix) {
+			eventTypes = append(eventTypes, category.prefix+"*")
+			continue
+		}
+		for _, name := range category.names {
+			if matchEventType(pattern, category.prefix+name) {
+				eventTypes = append(eventTypes, category.prefix+name)
+			}
+		}
+	}
+	return eventTypes
+}
+
+// closestEventTypes returns the supported event types an unknown one
+// is most likely a typo of, comparing case insensitively.
+func closestEventTypes(eventType string) []string {
+	// 3 is arbitrary and represents the max allowed number of typed errors
+	best := 3
+	var closest []string
+	for _, supportedEventType := range supportedEventTypes() {
+		distance := DamerauLevenshteinDistance(strings.ToLower(eventType), strings.ToLower(supportedEventType))
+		switch {
+		case distance < best:
+			best = distance
+			closest = []string{supportedEventType}
+		case distance == best:
+			closest = append(closest, supportedEventType)
+		}
+	}
+	sort.Strings(closest)
+	return closest
+}
+
+// unsupportedEventTypeError returns an error for an unknown event
+// type, suggesting the closest supported ones.
+func unsupportedEventTypeError(eventType string) error {
+	msg := fmt.Sprintf("unsupported event type ‘%s’", eventType)
+	if closest := closestEventTypes(eventType); len(closest) > 0 {
+		msg += fmt.Sprintf(", did you mean ‘%s’?", strings.Join(closest, "’ or ‘"))
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// parseEvents validates and expands event types, duplicates and empty
+// entries are dropped.
+func parseEvents(events []string) ([]string, error) {
+	var parsedEvents []string
+	seen := make(map[string]bool)
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if event == "" {
+			continue
+		}
+		eventTypes := expandEventType(event)
+		if len(eventTypes) == 0 {
+			return nil, unsupportedEventTypeError(event)
+		}
+		for _, eventType := range eventTypes {
+			if !seen[eventType] {
+				seen[eventType] = true
+				parsedEvents = append(parsedEvents, eventType)
+			}
+		}
+	}
+	return parsedEvents, nil
+}
@@ -0,0 +1,286 @@
+package main
+
+// Go lambda project layout, every file is executed with LambdaMetadata.
+var goLambdaTemplates = []lambdaTemplate{
+	{Name: "go.mod", Template: goModFile},
+	{Name: "main.go", Template: goMainFile},
+	{Name: "handler.go", Template: goHandlerFile},
+	{Name: "dispatch.go", Template: goDispatchFile},
+	{Name: "Makefile", Template: goMakeFile},
+	{Name: ".gitignore", Template: goGitignoreFile},
+	{Name: "README.md", Template: goReadmeFile},
+}
+
+// minio-go release generated lambdas are pinned to.
+const minioGoVersion = "v6.0.55"
+
+var goModFile = `module {{ .PackageName }}
+
+go 1.12
+
+require github.com/minio/minio-go/v6 ` + minioGoVersion + `
+`
+
+var goMainFile = `package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/minio/minio-go/v6"
+)
+
+// trigger selects the bucket notifications this lambda is invoked for.
+type trigger struct {
+	bucket string
+	prefix string
+	suffix string
+	events []string
+}
+
+// Bucket notification triggers this lambda was generated with, all of
+// them invoke the same handler.
+var triggers = []trigger{
+{{- range .Triggers }}
+	{
+		bucket: {{ printf "%q" .Bucket }},
+		prefix: {{ printf "%q" .Prefix }},
+		suffix: {{ printf "%q" .Suffix }},
+		events: []string{
+		{{- range $event := .Events }}
+			{{ printf "%q" $event }},
+		{{- end }}
+		},
+	},
+{{- end }}
+}
+
+func enableBucketNotification(s3Client *minio.Client, lambdaArn minio.Arn) error {
+	// ARN represents a notification channel that needs to be created in your S3 provider
+	//  (e.g. http://docs.aws.amazon.com/sns/latest/dg/CreateTopic.html)
+
+	// An example of an lambda ARN:
+	//             arn:minio:lambda:us-east-1:myfunc:lambda
+	//                  ^      ^      ^         ^       ^
+	//       Provider __|      |      |         |       |
+	//                         |    Region  Account ID  |_ Notification Name
+	//                Service _|
+	//
+	// You should replace YOUR-PROVIDER, YOUR-SERVICE, YOUR-REGION, YOUR-ACCOUNT-ID and YOUR-RESOURCE
+	// with actual values that you receive from the S3 provider
+
+	// Here you create a new lambda notification per trigger, they are
+	// grouped into a single notification config per bucket.
+	var buckets []string
+	bucketNotifications := make(map[string]*minio.BucketNotification)
+	for _, t := range triggers {
+		lambdaConfig := minio.NewNotificationConfig(lambdaArn)
+		for _, event := range t.events {
+			lambdaConfig.AddEvents(minio.NotificationEventType(event))
+		}
+		if t.prefix != "" {
+			lambdaConfig.AddFilterPrefix(t.prefix)
+		}
+		if t.suffix != "" {
+			lambdaConfig.AddFilterSuffix(t.suffix)
+		}
+		bucketNotification, ok := bucketNotifications[t.bucket]
+		if !ok {
+			bucketNotification = &minio.BucketNotification{}
+			bucketNotifications[t.bucket] = bucketNotification
+			buckets = append(buckets, t.bucket)
+		}
+		if !bucketNotification.AddLambda(lambdaConfig) {
+			return fmt.Errorf("overlapping triggers on bucket %s", t.bucket)
+		}
+	}
+
+	// Now, set all previously created notification configs
+	for _, bucket := range buckets {
+		if err := s3Client.SetBucketNotification(bucket, *bucketNotifications[bucket]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func listenBucketNotification(s3Client *minio.Client, lambdaFn LambdaFunc) error {
+	// Create a done channel to control 'ListenBucketNotification' go routines.
+	doneCh := make(chan struct{})
+
+	// Indicate background go-routines to exit cleanly upon return.
+	defer close(doneCh)
+
+	// Every trigger is listened on separately, notifications are all
+	// funneled into a single channel so that lambdaFn is never called
+	// concurrently.
+	notificationCh := make(chan minio.NotificationInfo)
+	var wg sync.WaitGroup
+	for _, t := range triggers {
+		wg.Add(1)
+		go func(t trigger) {
+			defer wg.Done()
+			for notificationInfo := range s3Client.ListenBucketNotification(t.bucket, t.prefix, t.suffix, t.events, doneCh) {
+				select {
+				case notificationCh <- notificationInfo:
+				case <-doneCh:
+					return
+				}
+			}
+		}(t)
+	}
+
+	// Close notificationCh once all the listeners stopped.
+	go func() {
+		wg.Wait()
+		close(notificationCh)
+	}()
+
+	for notificationInfo := range notificationCh {
+		if err := lambdaFn(notificationInfo.Records, notificationInfo.Err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func main() {
+	// Lambdas started by 'minl serve' are sent notifications by minl
+	// instead of listening on their own.
+	if os.Getenv("MINL_DISPATCH") == "1" {
+		if err := serveDispatch(YourFunc); err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to serve dispatched notifications.", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Requests are always secure (HTTPS) by default. Set secure=false to enable insecure (HTTP) access.
+	// This boolean value is the last argument for New().
+
+	// New returns an Amazon S3 compatible client object. API compatibility (v2 or v4) is automatically
+	// determined based on the Endpoint value.
+	s3Client, err := minio.New({{ printf "%q" .Endpoint }}, {{ printf "%q" .AccessKey }}, {{ printf "%q" .SecretKey }}, {{ .Secure }})
+	if err != nil {
+		fmt.Println("Unable to initialize minio client", err)
+		os.Exit(1)
+	}
+
+	// Create a new lambda ARN.
+	lambdaArn := minio.NewArn("minio", "lambda", {{ printf "%q" .Region }}, {{ printf "%q" .PackageName }}, "lambda")
+
+	// Enable bucket notifications, servers without a lambda target
+	// configured reject this but still allow listening below.
+	if err = enableBucketNotification(s3Client, lambdaArn); err != nil {
+		fmt.Println("Unable to enable bucket notification.", err)
+	}
+
+	// Listen bucket notification.
+	if err = listenBucketNotification(s3Client, YourFunc); err != nil {
+		fmt.Println("Unable to listen bucket notification.", err)
+		os.Exit(1)
+	}
+}
+`
+
+var goHandlerFile = `package main
+
+import (
+	"github.com/minio/minio-go/v6"
+)
+
+// LambdaFunc is called with every batch of bucket notification events.
+type LambdaFunc func(events []minio.NotificationEvent, err error) error
+
+// YourFunc handles bucket notification events, returning an error stops the lambda.
+func YourFunc(events []minio.NotificationEvent, err error) error {
+	if err != nil {
+		return err
+	}
+	/// Your code here.
+	return nil
+}
+`
+
+var goDispatchFile = `package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+
+	"github.com/minio/minio-go/v6"
+)
+
+// dispatchResponse is written back to minl for every notification.
+type dispatchResponse struct {
+	Error string ` + "`json:\"error,omitempty\"`" + `
+}
+
+// serveDispatch reads a notification per line of JSON on stdin and
+// writes back a dispatchResponse per line of JSON on stdout. Anything
+// else printed to stdout by the lambda is redirected to stderr.
+func serveDispatch(lambdaFn LambdaFunc) error {
+	out := json.NewEncoder(os.Stdout)
+	os.Stdout = os.Stderr
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var notificationInfo struct {
+			Records []minio.NotificationEvent
+		}
+		var resp dispatchResponse
+		if err := json.Unmarshal(scanner.Bytes(), &notificationInfo); err != nil {
+			resp.Error = err.Error()
+		} else if err = lambdaFn(notificationInfo.Records, nil); err != nil {
+			resp.Error = err.Error()
+		}
+		if err := out.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+`
+
+var goMakeFile = `all: build
+
+build: go.sum
+	@GO111MODULE=on go build -o {{ .PackageName }}
+
+go.sum: go.mod
+	@GO111MODULE=on go mod tidy
+
+run: build
+	@./{{ .PackageName }}
+
+clean:
+	@rm -f {{ .PackageName }}
+`
+
+var goGitignoreFile = `/{{ .PackageName }}
+`
+
+var goReadmeFile = `# {{ .PackageName }}
+
+MinIO lambda generated by minl, it runs ` + "`YourFunc`" + ` in handler.go
+for every bucket notification selected by its triggers.
+` + readmeTriggers + `
+## Build
+
+` + "```" + `
+make
+` + "```" + `
+
+The first build runs ` + "`go mod tidy`" + ` to download minio-go and write
+go.sum, which needs network access or a module cache that has it. Run
+` + "`go mod tidy`" + ` yourself before building with ` + "`go build`" + ` directly.
+
+## Run
+
+` + "```" + `
+./{{ .PackageName }}
+` + "```" + `
+`
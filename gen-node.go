@@ -0,0 +1,147 @@
+package main
+
+// Node.js lambda project layout, every file is executed with LambdaMetadata.
+var nodeLambdaTemplates = []lambdaTemplate{
+	{Name: "package.json", Template: nodePackageFile},
+	{Name: "main.js", Template: nodeMainFile},
+	{Name: "handler.js", Template: nodeHandlerFile},
+	{Name: "Makefile", Template: nodeMakeFile},
+	{Name: ".gitignore", Template: nodeGitignoreFile},
+	{Name: "README.md", Template: nodeReadmeFile},
+}
+
+var nodePackageFile = `{
+  "name": {{ printf "%q" .PackageName }},
+  "version": "0.0.1",
+  "private": true,
+  "main": "main.js",
+  "scripts": {
+    "start": "node main.js"
+  },
+  "dependencies": {
+    "minio": "^7.0.0"
+  }
+}
+`
+
+var nodeMainFile = `'use strict'
+
+const { yourFunc } = require('./handler')
+
+// Bucket notification triggers this lambda was generated with, all of
+// them invoke the same handler.
+const triggers = [
+{{- range .Triggers }}
+  {
+    bucket: {{ printf "%q" .Bucket }},
+    prefix: {{ printf "%q" .Prefix }},
+    suffix: {{ printf "%q" .Suffix }},
+    events: [
+    {{- range $event := .Events }}
+      {{ printf "%q" $event }},
+    {{- end }}
+    ]
+  },
+{{- end }}
+]
+
+// dispatch serves notifications sent by 'minl serve', a notification per
+// line of JSON on stdin and a response per line of JSON on stdout.
+// Anything else logged by the lambda goes to stderr.
+function dispatch () {
+  const out = process.stdout
+  console.log = console.error
+  const lines = require('readline').createInterface({ input: process.stdin })
+  lines.on('line', line => {
+    const resp = {}
+    try {
+      yourFunc(JSON.parse(line).Records || [], null)
+    } catch (err) {
+      resp.error = String(err)
+    }
+    out.write(JSON.stringify(resp) + '\n')
+  })
+}
+
+function listen () {
+  const Minio = require('minio')
+  const [endPoint, port] = {{ printf "%q" .Endpoint }}.split(':')
+
+  const client = new Minio.Client({
+    endPoint: endPoint,
+    port: port ? parseInt(port, 10) : undefined,
+    useSSL: {{ .Secure }},
+    accessKey: {{ printf "%q" .AccessKey }},
+    secretKey: {{ printf "%q" .SecretKey }},
+    region: {{ printf "%q" .Region }} || undefined
+  })
+
+  // Node.js runs a single callback at a time, so yourFunc is never
+  // called concurrently even with a listener per trigger.
+  const listeners = triggers.map(t => client.listenBucketNotification(t.bucket, t.prefix, t.suffix, t.events))
+  listeners.forEach(listener => {
+    listener.on('notification', record => {
+      try {
+        yourFunc([record], null)
+      } catch (err) {
+        console.log('Unable to handle bucket notification.', err)
+        listeners.forEach(l => l.stop())
+        process.exit(1)
+      }
+    })
+    listener.on('error', err => {
+      console.log('Unable to listen bucket notification.', err)
+      process.exit(1)
+    })
+  })
+}
+
+if (process.env.MINL_DISPATCH === '1') {
+  dispatch()
+} else {
+  listen()
+}
+`
+
+var nodeHandlerFile = `'use strict'
+
+// yourFunc handles bucket notification events, throwing stops the lambda.
+function yourFunc (events, err) {
+  if (err) {
+    throw err
+  }
+  // Your code here.
+}
+
+module.exports = { yourFunc }
+`
+
+var nodeMakeFile = `all: deps
+
+deps:
+	@npm install
+
+run:
+	@node main.js
+`
+
+var nodeGitignoreFile = `node_modules/
+`
+
+var nodeReadmeFile = `# {{ .PackageName }}
+
+MinIO lambda generated by minl, it runs ` + "`yourFunc`" + ` in handler.js
+for every bucket notification selected by its triggers.
+` + readmeTriggers + `
+## Install dependencies
+
+` + "```" + `
+make deps
+` + "```" + `
+
+## Run
+
+` + "```" + `
+make run
+` + "```" + `
+`
@@ -0,0 +1,138 @@
+package main
+
+// Python lambda project layout, every file is executed with LambdaMetadata.
+var pythonLambdaTemplates = []lambdaTemplate{
+	{Name: "requirements.txt", Template: pythonRequirementsFile},
+	{Name: "main.py", Template: pythonMainFile, Mode: 0755},
+	{Name: "handler.py", Template: pythonHandlerFile},
+	{Name: "Makefile", Template: pythonMakeFile},
+	{Name: ".gitignore", Template: pythonGitignoreFile},
+	{Name: "README.md", Template: pythonReadmeFile},
+}
+
+var pythonRequirementsFile = `minio>=5.0.10,<6
+`
+
+var pythonMainFile = `#!/usr/bin/env python3
+"""{{ .PackageName }} - MinIO lambda generated by minl."""
+
+import json
+import os
+import queue
+import sys
+import threading
+
+from handler import your_func
+
+# Bucket notification triggers this lambda was generated with, all of
+# them invoke the same handler.
+TRIGGERS = [
+{{- range .Triggers }}
+    {
+        "bucket": {{ printf "%q" .Bucket }},
+        "prefix": {{ printf "%q" .Prefix }},
+        "suffix": {{ printf "%q" .Suffix }},
+        "events": [
+        {{- range $event := .Events }}
+            {{ printf "%q" $event }},
+        {{- end }}
+        ],
+    },
+{{- end }}
+]
+
+def dispatch():
+    """Serves notifications sent by 'minl serve', a notification per line
+    of JSON on stdin and a response per line of JSON on stdout. Anything
+    else printed by the lambda goes to stderr."""
+    out = sys.stdout
+    sys.stdout = sys.stderr
+    for line in sys.stdin:
+        resp = {}
+        try:
+            your_func(json.loads(line).get("Records", []), None)
+        except Exception as err:
+            resp["error"] = str(err)
+        out.write(json.dumps(resp) + "\n")
+        out.flush()
+
+
+def main():
+    if os.environ.get("MINL_DISPATCH") == "1":
+        dispatch()
+        return
+
+    from minio import Minio
+    client = Minio({{ printf "%q" .Endpoint }},
+                   access_key={{ printf "%q" .AccessKey }},
+                   secret_key={{ printf "%q" .SecretKey }},
+                   secure={{ if .Secure }}True{{ else }}False{{ end }},
+                   region={{ printf "%q" .Region }} or None)
+
+    # Every trigger is listened on in its own thread, notifications are
+    # all handled here so that your_func is never called concurrently.
+    notifications = queue.Queue()
+
+    def listen(trigger):
+        try:
+            for notification in client.listen_bucket_notification(
+                    trigger["bucket"], trigger["prefix"], trigger["suffix"], trigger["events"]):
+                notifications.put((notification, None))
+        except Exception as err:
+            notifications.put((None, err))
+
+    for trigger in TRIGGERS:
+        threading.Thread(target=listen, args=(trigger,), daemon=True).start()
+
+    while True:
+        notification, err = notifications.get()
+        if err is not None:
+            print("Unable to listen bucket notification.", err)
+            sys.exit(1)
+        try:
+            your_func(notification.get("Records", []), None)
+        except Exception as err:
+            print("Unable to handle bucket notification.", err)
+            sys.exit(1)
+
+if __name__ == "__main__":
+    main()
+`
+
+var pythonHandlerFile = `def your_func(events, err):
+    """Handles bucket notification events, raising an exception stops the lambda."""
+    if err is not None:
+        raise err
+    # Your code here.
+`
+
+var pythonMakeFile = `all: deps
+
+deps:
+	@pip3 install -r requirements.txt
+
+run:
+	@python3 main.py
+`
+
+var pythonGitignoreFile = `__pycache__/
+*.pyc
+`
+
+var pythonReadmeFile = `# {{ .PackageName }}
+
+MinIO lambda generated by minl, it runs ` + "`your_func`" + ` in handler.py
+for every bucket notification selected by its triggers.
+` + readmeTriggers + `
+## Install dependencies
+
+` + "```" + `
+make deps
+` + "```" + `
+
+## Run
+
+` + "```" + `
+make run
+` + "```" + `
+`
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Shell lambda project layout, every file is executed with LambdaMetadata.
+var shellLambdaTemplates = []lambdaTemplate{
+	{Name: "main.sh", Template: shellMainFile, Mode: 0755},
+	{Name: "handler.sh", Template: shellHandlerFile},
+	{Name: "Makefile", Template: shellMakeFile},
+	{Name: "README.md", Template: shellReadmeFile},
+}
+
+// shellQuote quotes s as a single word for sh.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// listenURL returns the URL 'curl' listens on bucket notifications of
+// trigger with, like minio-go's ListenBucketNotification.
+func listenURL(lmeta LambdaMetadata, trigger Trigger) string {
+	scheme := "http"
+	if lmeta.Secure {
+		scheme = "https"
+	}
+	urlValues := make(url.Values)
+	urlValues.Set("prefix", trigger.Prefix)
+	urlValues.Set("suffix", trigger.Suffix)
+	urlValues["events"] = trigger.Events
+	u := url.URL{
+		Scheme:   scheme,
+		Host:     lmeta.Endpoint,
+		Path:     "/" + trigger.Bucket,
+		RawQuery: urlValues.Encode(),
+	}
+	return u.String()
+}
+
+// sigV4Region returns the region requests of lambda are signed for.
+func sigV4Region(lmeta LambdaMetadata) string {
+	if lmeta.Region == "" {
+		return "us-east-1"
+	}
+	return lmeta.Region
+}
+
+var shellMainFile = `#!/bin/sh
+# {{ .PackageName }} - MinIO lambda generated by minl, requires curl 7.75 or
+# later in PATH.
+
+. "$(dirname "$0")/handler.sh"
+
+# handle passes the event record of a notification, a line of JSON, to
+# your_func. Notifications sent by 'minl serve' and MinIO carry a single
+# record, blank lines are keep alives.
+handle() {
+	case "$1" in
+	*'"Records":['*) ;;
+	*) return 0 ;;
+	esac
+	record=${1#*'"Records":['}
+	your_func "${record%']'*}"
+}
+
+# Lambdas started by 'minl serve' are sent a notification per line of
+# JSON on stdin and write back a response per line of JSON on stdout.
+if [ "$MINL_DISPATCH" = "1" ]; then
+	while read -r notification; do
+		if handle "$notification" >&2; then
+			echo '{}'
+		else
+			echo '{"error":"your_func failed"}'
+		fi
+	done
+	exit 0
+fi
+
+# listen streams bucket notifications from URL "$1", reconnecting when
+# the stream breaks until curl fails writing them, 23, once the lambda
+# is gone.
+listen() {
+	while curl -sSfN --aws-sigv4 {{ shellQuote (print "aws:amz:" (sigV4Region .) ":s3") }} \
+		--user {{ shellQuote (print .AccessKey ":" .SecretKey) }} "$1"; [ $? -ne 23 ]; do
+		sleep 1
+	done
+}
+
+# watch_triggers listens for every trigger this lambda was generated
+# with, all of them feed the same handler.
+watch_triggers() {
+{{- range .Triggers }}
+	listen {{ shellQuote (listenURL $ .) }} &
+{{- end }}
+	wait
+}
+
+watch_triggers |
+while read -r notification; do
+	if ! handle "$notification"; then
+		echo "Unable to handle bucket notification." >&2
+		exit 1
+	fi
+done
+`
+
+var shellHandlerFile = `# your_func handles the event record of a bucket notification passed
+# as JSON in "$1", returning non-zero stops the lambda.
+your_func() {
+	# Your code here.
+	return 0
+}
+`
+
+var shellMakeFile = `all: run
+
+run:
+	@./main.sh
+`
+
+var shellReadmeFile = `# {{ .PackageName }}
+
+MinIO lambda generated by minl, it runs ` + "`your_func`" + ` in handler.sh
+for every bucket notification selected by its triggers. ` + "`your_func`" + `
+gets a single event record as JSON in ` + "`$1`" + `, the same whether the
+lambda listens on its own or is run by ` + "`minl serve`" + `:
+
+` + "```" + `json
+{"eventVersion":"2.0","eventSource":"minio:s3","eventName":"s3:ObjectCreated:Put","s3":{"bucket":{"name":"..."},"object":{"key":"..."}},...}
+` + "```" + `
+` + readmeTriggers + `
+## Run
+
+Requires curl 7.75 or later in PATH.
+
+` + "```" + `
+make run
+` + "```" + `
+`
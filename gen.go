@@ -1,9 +1,13 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"go/format"
+	"io/ioutil"
 	"os"
 	"path"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -16,6 +20,14 @@ var genCmd = cli.Command{
 	Usage:  "Generates lambda",
 	Action: mainGen,
 	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "manifest",
+			Usage: "Manifest to generate lambda from, replaces all other flags.",
+		},
+		cli.StringSliceFlag{
+			Name:  "trigger",
+			Usage: "Trigger as BUCKET:PREFIX:SUFFIX:EVENTS, may be repeated. Empty EVENTS defaults to --events.",
+		},
 		cli.StringFlag{
 			Name:  "bucket",
 			Usage: "Bucket to run lambda on.",
@@ -28,6 +40,11 @@ var genCmd = cli.Command{
 			Name:  "suffix",
 			Usage: "Suffix to run lambda on.",
 		},
+		cli.StringFlag{
+			Name:  "runtime",
+			Usage: "Runtime of the lambda, one of go, python, node or shell.",
+			Value: "go",
+		},
 		cli.StringFlag{
 			Name:  "events",
 			Usage: "Events to run lambda on.",
@@ -38,7 +55,7 @@ var genCmd = cli.Command{
    minl {{.Name}} - {{.Usage}}
 
 USAGE:
-   minl {{.Name}} [FLAGS]
+   minl {{.Name}} [FLAGS] LAMBDA-DIR
 
 FLAGS:
   {{range .Flags}}{{.}}
@@ -49,146 +66,80 @@ FLAGS:
 // LambdaMetadata struct.
 type LambdaMetadata struct {
 	PackageName string
+	Runtime     string
 	Endpoint    string
 	AccessKey   string
 	SecretKey   string
 	Secure      bool
 	Region      string
-	Bucket      string
-	Events      []string
-	Prefix      string
-	Suffix      string
-}
-
-var notificationFile = `
-package main
-
-import (
-        "fmt"
-        "github.com/minio/minio-go/v6"
-)
-
-func enableBucketNotification(s3Client *minio.Client, lambdaArn minio.Arn) error {
-       // ARN represents a notification channel that needs to be created in your S3 provider
-       //  (e.g. http://docs.aws.amazon.com/sns/latest/dg/CreateTopic.html)
-
-       // An example of an lambda ARN:
-       //             arn:minio:lambda:us-east-1:myfunc:lambda
-       //                  ^      ^      ^         ^       ^
-       //       Provider __|      |      |         |       |
-       //                         |    Region  Account ID  |_ Notification Name
-       //                Service _|
-       //
-       // You should replace YOUR-PROVIDER, YOUR-SERVICE, YOUR-REGION, YOUR-ACCOUNT-ID and YOUR-RESOURCE
-       // with actual values that you receive from the S3 provider
-
-       // Here you create a new lambda notification
-       lambdaConfig := minio.NewNotificationConfig(lambdaArn)
-       {{range $event := .Events}}
-       lambdaConfig.AddEvents(minio.NotificationEventType("{{ $event }}"))
-       {{end}}
-       {{if .Prefix}} lambdaConfig.AddFilterPrefix("{{ .Prefix }}") {{end}}
-       {{if .Suffix}} lambdaConfig.AddFilterSuffix("{{ .Suffix }}") {{end}}
-
-       // Now, set all previously created notification configs
-       bucketNotification := minio.BucketNotification{}
-       bucketNotification.AddLambda(lambdaConfig)
-       err := s3Client.SetBucketNotification("{{ .Bucket }}", bucketNotification)
-       if err != nil {
-            return err
-       }
-       return nil
+	Triggers    []Trigger
+	Profile     string
+	Limits      LambdaLimits
+	Env         map[string]string
 }
 
-func listenBucketNotification(s3Client *minio.Client, bucket string, lambdaArn minio.Arn, lambdaFn LambdaFunc) error {
-       // Create a done channel to control 'ListenBucketNotification' go routine.
-       doneCh := make(chan struct{})
-
-       // Indicate a background go-routine to exit cleanly upon return.
-       defer close(doneCh)
-
-       // Notification account ARN, variable fields to note here are:
-       //  - region
-       //  - account-id
-       // Account id here is the same which was chosen during SetBucketNotification.
-       for notificationInfo := range s3Client.ListenBucketNotification(bucket, lambdaArn, doneCh) {
-               if err := lambdaFn(notificationInfo.Records, notificationInfo.Err); err != nil {
-                       return err
-               }
-       }
-       return nil
+// lambdaTemplate is a single file of a generated lambda project,
+// Name is relative to the lambda directory.
+type lambdaTemplate struct {
+	Name     string
+	Template string
+	Mode     os.FileMode // defaults to 0644 when unset.
 }
 
-func main() {
-       // Requests are always secure (HTTPS) by default. Set secure=false to enable insecure (HTTP) access.
-       // This boolean value is the last argument for New().
-
-       // New returns an Amazon S3 compatible client object. API compatibility (v2 or v4) is automatically
-       // determined based on the Endpoint value.
-       s3Client, err := minio.New("{{ .Endpoint }}", "{{ .AccessKey }}", "{{ .SecretKey }}", {{ .Secure }})
-       if err != nil {
-             fmt.Println("Unable to initialize minio client", err)
-             return
-       }
-
-       // Create a new lambda ARN.
-       lambdaArn := minio.NewArn("minio", "lambda", "{{ .Region }}", "{{ .PackageName }}", "lambda")
-
-       // Enable bucket notifications.
-       if err = enableBucketNotification(s3Client, lambdaArn); err != nil {
-              fmt.Println("Unable to enable bucket notification.", err)
-              return
-       }     
-
-       // Listen bucket notification.
-       if err = listenBucketNotification(s3Client, "{{ .Bucket }}", lambdaArn, YourFunc); err != nil {
-              fmt.Println("Unable to listen bucket notification.", err)
-              return
-       }
+// Project layouts for all the supported lambda runtimes.
+var runtimeTemplates = map[string][]lambdaTemplate{
+	"go":     goLambdaTemplates,
+	"python": pythonLambdaTemplates,
+	"node":   nodeLambdaTemplates,
+	"shell":  shellLambdaTemplates,
 }
 
-type LambdaFunc func(events []minio.NotificationEvent, err error) error
-
-func YourFunc(events []minio.NotificationEvent, err error) error {
-       if err != nil {
-               return err
-       }
-       /// Your code here.
-       return nil
-}
-`
-
-var supportedEventTypes = []string{
-	"s3:ObjectCreated:*",
-	"s3:ObjectCreated:Put",
-	"s3:ObjectCreated:Post",
-	"s3:ObjectCreated:Copy",
-	"sh:ObjectCreated:CompleteMultipartUpload",
-	"s3:ObjectRemoved:*",
-	"s3:ObjectRemoved:Delete",
-}
-
-func isValidEventType(eventType string) bool {
-	for _, supportedEventType := range supportedEventTypes {
-		if supportedEventType == eventType {
-			return true
-		}
+// supportedRuntimes returns sorted names of all the lambda runtimes.
+func supportedRuntimes() []string {
+	var runtimes []string
+	for runtime := range runtimeTemplates {
+		runtimes = append(runtimes, runtime)
 	}
-	return false
+	sort.Strings(runtimes)
+	return runtimes
 }
 
-func parseEvents(events []string) (parsedEvents []string) {
-	for _, eventType := range events {
-		if isValidEventType(eventType) {
-			parsedEvents = append(parsedEvents, eventType)
+// readmeTriggers lists a lambda's triggers in the README of every runtime.
+var readmeTriggers = `
+## Triggers
+
+{{ range .Triggers }}- bucket ` + "`{{ .Bucket }}`" + `
+{{- if .Prefix }}, prefix ` + "`{{ .Prefix }}`" + `{{ end }}
+{{- if .Suffix }}, suffix ` + "`{{ .Suffix }}`" + `{{ end }}: {{ join .Events ", " }}
+{{ end }}`
+
+// parseTrigger parses a BUCKET:PREFIX:SUFFIX:EVENTS trigger, where
+// EVENTS is a comma separated list which defaults to defaultEvents.
+func parseTrigger(spec string, defaultEvents []string) (Trigger, error) {
+	// Event types have colons of their own, they are all in the last field.
+	fields := strings.SplitN(spec, ":", 4)
+	if len(fields) < 3 {
+		return Trigger{}, fmt.Errorf("invalid trigger ‘%s’, expected BUCKET:PREFIX:SUFFIX:EVENTS", spec)
+	}
+	trigger := Trigger{
+		Bucket: fields[0],
+		Prefix: fields[1],
+		Suffix: fields[2],
+		Events: defaultEvents,
+	}
+	if len(fields) == 4 && fields[3] != "" {
+		events, err := parseEvents(strings.Split(fields[3], ","))
+		if err != nil {
+			return Trigger{}, err
 		}
+		trigger.Events = events
 	}
-	return parsedEvents
+	return trigger, nil
 }
 
 // checkGenSyntax - validate all the passed arguments
 func checkGenSyntax(ctx *cli.Context) {
-	if !ctx.Args().Present() {
+	if !ctx.Args().Present() && ctx.String("manifest") == "" {
 		cli.ShowCommandHelpAndExit(ctx, ctx.Args().First(), 1)
 	}
 }
@@ -197,41 +148,114 @@ func initLambdaDir(lambda string) error {
 	return os.MkdirAll(lambda, 0755)
 }
 
-func newLambdaMeta(ctx *cli.Context) LambdaMetadata {
+// newLambdaMeta builds lambda metadata from the manifest when one is
+// passed, otherwise from command line flags.
+func newLambdaMeta(ctx *cli.Context) (LambdaMetadata, error) {
+	if manifest := ctx.String("manifest"); manifest != "" {
+		m, err := loadManifestFile(manifest)
+		if err != nil {
+			return LambdaMetadata{}, err
+		}
+		return newLambdaMetaFromManifest(m), nil
+	}
+
+	cfg := newS3ConfigFromEnv()
 	lmeta := LambdaMetadata{
-		PackageName: ctx.Args().First(),
-		Endpoint:    os.Getenv("S3_ENDPOINT"),
-		AccessKey:   os.Getenv("ACCESS_KEY"),
-		SecretKey:   os.Getenv("SECRET_KEY"),
-		Secure:      os.Getenv("S3_SECURE") == "1",
-		Region:      os.Getenv("S3_REGION"),
-		Bucket:      ctx.String("bucket"),
-		Events:      parseEvents(strings.Split(ctx.String("events"), ",")),
-		Prefix:      ctx.String("prefix"),
-		Suffix:      ctx.String("suffix"),
-	}
-	return lmeta
+		PackageName: path.Base(ctx.Args().First()),
+		Runtime:     ctx.String("runtime"),
+		Endpoint:    cfg.Endpoint,
+		AccessKey:   cfg.AccessKey,
+		SecretKey:   cfg.SecretKey,
+		Secure:      cfg.Secure,
+		Region:      cfg.Region,
+	}
+
+	events, err := parseEvents(strings.Split(ctx.String("events"), ","))
+	if err != nil {
+		return lmeta, err
+	}
+	if specs := ctx.StringSlice("trigger"); len(specs) > 0 {
+		for _, spec := range specs {
+			trigger, err := parseTrigger(spec, events)
+			if err != nil {
+				return lmeta, err
+			}
+			lmeta.Triggers = append(lmeta.Triggers, trigger)
+		}
+	} else {
+		lmeta.Triggers = []Trigger{{
+			Bucket: ctx.String("bucket"),
+			Events: events,
+			Prefix: ctx.String("prefix"),
+			Suffix: ctx.String("suffix"),
+		}}
+	}
+	return lmeta, lmeta.Manifest().Validate()
+}
+
+// genLambda executes all the lambda project templates into lambda directory.
+func genLambda(lambda string, lmeta LambdaMetadata, templates []lambdaTemplate) error {
+	funcs := template.FuncMap{
+		"join":        strings.Join,
+		"shellQuote":  shellQuote,
+		"listenURL":   listenURL,
+		"sigV4Region": sigV4Region,
+	}
+	for _, t := range templates {
+		tmpl, err := template.New(t.Name).Funcs(funcs).Parse(t.Template)
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err = tmpl.Execute(&buf, lmeta); err != nil {
+			return err
+		}
+		data := buf.Bytes()
+		if strings.HasSuffix(t.Name, ".go") {
+			// Generated Go code is always gofmt'ed.
+			if data, err = format.Source(data); err != nil {
+				return fmt.Errorf("%s: %s", t.Name, err)
+			}
+		}
+		mode := t.Mode
+		if mode == 0 {
+			mode = 0644
+		}
+		if err = ioutil.WriteFile(path.Join(lambda, t.Name), data, mode); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func mainGen(ctx *cli.Context) {
 	checkGenSyntax(ctx)
 
+	lmeta, err := newLambdaMeta(ctx)
+	if err != nil {
+		fmt.Println("Invalid lambda.", err)
+		os.Exit(1)
+	}
+
+	// Lambda directory defaults to the name of the lambda.
 	lambda := ctx.Args().First()
-	tmpl := template.Must(template.New(lambda).Parse(notificationFile))
+	if lambda == "" {
+		lambda = lmeta.PackageName
+	}
+	templates := runtimeTemplates[lmeta.Runtime]
 
-	initLambdaDir(lambda)
-	lmeta := newLambdaMeta(ctx)
+	if err = initLambdaDir(lambda); err != nil {
+		fmt.Println("Unable to create", lambda, err)
+		os.Exit(1)
+	}
 
-	templateFile := path.Join(lambda, lambda+".go")
-	w, err := os.Create(templateFile)
-	if err != nil {
-		fmt.Println("Unable to write", templateFile, err)
-		return
+	if err = genLambda(lambda, lmeta, templates); err != nil {
+		fmt.Println("Unable to generate lambda", lambda, err)
+		os.Exit(1)
 	}
-	defer w.Close()
-	err = tmpl.Execute(w, lmeta)
-	if err != nil {
-		fmt.Println("Unable to write", templateFile, err)
-		return
+
+	if err = saveManifest(lambda, lmeta.Manifest()); err != nil {
+		fmt.Println("Unable to generate lambda", lambda, err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/minio/cli"
+)
+
+// Invoke lambda.
+var invokeCmd = cli.Command{
+	Name:   "invoke",
+	Usage:  "Invoke lambda with a recorded or synthetic event",
+	Action: mainInvoke,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "event",
+			Usage: "Event to deliver as JSON, a notification or a single event record, '-' reads stdin.",
+		},
+		cli.StringFlag{
+			Name:  "synthesize",
+			Usage: "Synthesize an event, one of put, copy, delete, get, head or an event type.",
+		},
+		cli.StringFlag{
+			Name:  "bucket",
+			Usage: "Bucket of the synthesized event, defaults to the bucket of the first trigger.",
+		},
+		cli.StringFlag{
+			Name:  "key",
+			Usage: "Object key of the synthesized event.",
+		},
+		cli.Int64Flag{
+			Name:  "size",
+			Usage: "Object size of the synthesized event.",
+		},
+		cli.StringFlag{
+			Name:  "profile",
+			Usage: "Seccomp profile to confine lambda with, overrides the manifest.",
+		},
+		cli.BoolFlag{
+			Name:  "strict",
+			Usage: "Fail to start lambda when its seccomp profile has unknown syscalls, instead of skipping their rules.",
+		},
+	},
+	CustomHelpTemplate: `NAME:
+   minl {{.Name}} - {{.Usage}}
+
+USAGE:
+   minl {{.Name}} [FLAGS] LAMBDA-DIR
+
+FLAGS:
+  {{range .Flags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Invoke lambda with a recorded event.
+      $ minl {{.Name}} --event event.json thumbnailer
+
+   2. Invoke lambda with an upload of a 1MiB object.
+      $ minl {{.Name}} --synthesize put --bucket images --key cat.jpg --size 1048576 thumbnailer
+
+`,
+}
+
+// Event types synthesized for the short names of --synthesize.
+var synthesizedEvents = map[string]string{
+	"put":    "s3:ObjectCreated:Put",
+	"copy":   "s3:ObjectCreated:Copy",
+	"delete": "s3:ObjectRemoved:Delete",
+	"get":    "s3:ObjectAccessed:Get",
+	"head":   "s3:ObjectAccessed:Head",
+}
+
+// checkInvokeSyntax - validate all the passed arguments
+func checkInvokeSyntax(ctx *cli.Context) {
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+	if (ctx.String("event") == "") == (ctx.String("synthesize") == "") {
+		fmt.Println("Exactly one of --event or --synthesize is required.")
+		os.Exit(1)
+	}
+}
+
+// readEvent reads a recorded notification, either as delivered to
+// lambdas or a single event record.
+func readEvent(name string) (NotificationInfo, error) {
+	var notificationInfo NotificationInfo
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(name)
+	}
+	if err != nil {
+		return notificationInfo, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return notificationInfo, fmt.Errorf("%s: %s", name, err)
+	}
+	if _, ok := fields["Records"]; ok {
+		err = json.Unmarshal(data, &notificationInfo)
+	} else {
+		var event NotificationEvent
+		err = json.Unmarshal(data, &event)
+		notificationInfo.Records = []NotificationEvent{event}
+	}
+	if err != nil {
+		return notificationInfo, fmt.Errorf("%s: %s", name, err)
+	}
+	if len(notificationInfo.Records) == 0 {
+		return notificationInfo, fmt.Errorf("%s: no event records", name)
+	}
+	return notificationInfo, nil
+}
+
+// synthesizeEvent builds an event as it would be sent by the server.
+func synthesizeEvent(ctx *cli.Context, lmeta LambdaMetadata) (NotificationInfo, error) {
+	eventName := ctx.String("synthesize")
+	if name, ok := synthesizedEvents[eventName]; ok {
+		eventName = name
+	} else if !isValidEventType(eventName) || strings.HasSuffix(eventName, "*") {
+		return NotificationInfo{}, unsupportedEventTypeError(eventName)
+	}
+
+	bucket := ctx.String("bucket")
+	if bucket == "" {
+		bucket = lmeta.Triggers[0].Bucket
+	}
+	key := ctx.String("key")
+	if key == "" {
+		return NotificationInfo{}, errors.New("--key is required to synthesize an event")
+	}
+	object := ObjectMeta{
+		Key:       key,
+		Sequencer: fmt.Sprintf("%016X", time.Now().UnixNano()),
+	}
+	// Removal events carry no object details.
+	if !strings.HasPrefix(eventName, "s3:ObjectRemoved:") {
+		object.Size = ctx.Int64("size")
+		object.ContentType = "application/octet-stream"
+	}
+	region := lmeta.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	event := newNotificationEvent(eventName, region, lmeta.AccessKey, bucket, object)
+	return NotificationInfo{Records: []NotificationEvent{event}}, nil
+}
+
+func mainInvoke(ctx *cli.Context) {
+	checkInvokeSyntax(ctx)
+
+	lambdaDir := ctx.Args().First()
+	m, err := loadManifest(lambdaDir)
+	if err != nil {
+		fmt.Println("Unable to load lambda.", err)
+		os.Exit(1)
+	}
+	lmeta := newLambdaMetaFromManifest(m)
+
+	var notificationInfo NotificationInfo
+	if event := ctx.String("event"); event != "" {
+		notificationInfo, err = readEvent(event)
+	} else {
+		notificationInfo, err = synthesizeEvent(ctx, lmeta)
+	}
+	if err != nil {
+		fmt.Println("Unable to prepare event.", err)
+		os.Exit(1)
+	}
+	for _, event := range notificationInfo.Records {
+		if !matchNotification(lmeta, event) {
+			fmt.Printf("Warning: %s event on %s/%s is not selected by any trigger of lambda %s.\n",
+				event.EventName, event.S3.Bucket.Name, event.S3.Object.Key, lmeta.PackageName)
+		}
+	}
+
+	profile := ctx.String("profile")
+	if profile == "" {
+		profile = lambdaProfile(lambdaDir, lmeta)
+	}
+	if profile != "" {
+		if _, err = loadSeccompProfile(profile); err != nil {
+			fmt.Println("Unable to load seccomp profile.", err)
+			os.Exit(1)
+		}
+	}
+
+	args, err := lambdaCommand(lambdaDir, lmeta)
+	if err != nil {
+		fmt.Println("Unable to prepare lambda.", err)
+		os.Exit(1)
+	}
+	cmd, err := sandboxCommand(lambdaDir, profile, ctx.Bool("strict"), lmeta, args)
+	if err != nil {
+		fmt.Println("Unable to prepare lambda.", err)
+		os.Exit(1)
+	}
+
+	// The event goes through the same dispatch protocol 'minl serve'
+	// uses, the timing includes starting the lambda.
+	start := time.Now()
+	proc, err := startHandlerProcess(cmd)
+	if err != nil {
+		fmt.Println("Unable to start lambda.", err)
+		os.Exit(1)
+	}
+	resp, deliverErr := proc.exchange(notificationInfo)
+	handled := time.Since(start)
+	if deliverErr != nil {
+		proc.kill()
+	} else {
+		proc.stop()
+	}
+
+	failed := false
+	switch {
+	case deliverErr != nil:
+		fmt.Println("Result: no response,", deliverErr)
+		failed = true
+	case resp.Error != "":
+		fmt.Println("Result: failed,", resp.Error)
+		failed = true
+	default:
+		fmt.Println("Result: success")
+	}
+	fmt.Println("Exit:", exitReason(proc.cmd.ProcessState))
+	fmt.Printf("Duration: %s handling, %s total\n", handled.Round(time.Microsecond), time.Since(start).Round(time.Microsecond))
+	if failed || !proc.cmd.ProcessState.Success() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The journal is a directory of JSON lines files, a journalEntry per
+// line. Entries are appended to the current file which is rotated once
+// it grows too large, rotated files are named after the time of
+// rotation so that sorting them by name orders them in time.
+const (
+	journalCurrent    = "current.jsonl"
+	journalPrefix     = "journal-"
+	journalTimeFormat = "20060102T150405.000000000Z"
+)
+
+// journalEntry is a notification as it was received from the server,
+// or one that could not be delivered to the lambda named Lambda when
+// Error is set.
+type journalEntry struct {
+	Time             time.Time        `json:"time"`
+	Bucket           string           `json:"bucket"`
+	NotificationInfo NotificationInfo `json:"notification"`
+	Lambda           string           `json:"lambda,omitempty"`
+	Error            string           `json:"error,omitempty"`
+}
+
+// journal appends notifications to an on-disk journal.
+type journal struct {
+	dir      string
+	maxSize  int64
+	compress bool
+
+	mu   sync.Mutex
+	file *os.File // nil when it could not be reopened.
+	size int64
+
+	// Rotated files being compressed.
+	compressing sync.WaitGroup
+}
+
+// openJournal opens the journal in dir for appending, creating it if
+// needed. Files are rotated after maxSize bytes and gzip'ed when
+// compress is set.
+func openJournal(dir string, maxSize int64, compress bool) (*journal, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	j := &journal{dir: dir, maxSize: maxSize, compress: compress}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// open opens the current journal file.
+func (j *journal) open() error {
+	f, err := os.OpenFile(filepath.Join(j.dir, journalCurrent), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	j.file, j.size = f, fi.Size()
+	return nil
+}
+
+// record appends a notification received on bucket to the journal.
+func (j *journal) record(bucket string, notificationInfo NotificationInfo) error {
+	return j.append(journalEntry{
+		Time:             time.Now().UTC(),
+		Bucket:           bucket,
+		NotificationInfo: notificationInfo,
+	})
+}
+
+// recordFailure appends a notification that could not be delivered to
+// lambda to the journal.
+func (j *journal) recordFailure(lambda string, notificationInfo NotificationInfo, err error) error {
+	var bucket string
+	if len(notificationInfo.Records) > 0 {
+		bucket = notificationInfo.Records[0].S3.Bucket.Name
+	}
+	return j.append(journalEntry{
+		Time:             time.Now().UTC(),
+		Bucket:           bucket,
+		NotificationInfo: notificationInfo,
+		Lambda:           lambda,
+		Error:            err.Error(),
+	})
+}
+
+// append writes an entry to the current file, rotating it when full.
+func (j *journal) append(entry journalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		if err = j.open(); err != nil {
+			return err
+		}
+	}
+	n, err := j.file.Write(append(data, '\n'))
+	j.size += int64(n)
+	if err != nil {
+		return err
+	}
+	if j.maxSize > 0 && j.size >= j.maxSize {
+		rotated, err := j.rotate()
+		if rotated != "" && j.compress {
+			// Compressing takes a while, it must not hold up
+			// the notifications recorded meanwhile.
+			j.compressing.Add(1)
+			go func() {
+				defer j.compressing.Done()
+				if err := compressFile(rotated); err != nil {
+					fmt.Println("Unable to compress journal file.", err)
+				}
+			}()
+		}
+		return err
+	}
+	return nil
+}
+
+// rotate moves the current file aside and starts a new one, returns
+// the name of the file moved aside, empty if it was not. A new file is
+// opened whatever fails, or on the next append.
+func (j *journal) rotate() (string, error) {
+	err := j.file.Close()
+	j.file = nil
+	var rotated string
+	if err == nil {
+		rotated = filepath.Join(j.dir, journalPrefix+time.Now().UTC().Format(journalTimeFormat)+".jsonl")
+		if err = os.Rename(filepath.Join(j.dir, journalCurrent), rotated); err != nil {
+			rotated = ""
+		}
+	}
+	if oerr := j.open(); err == nil {
+		err = oerr
+	}
+	return rotated, err
+}
+
+// Close closes the current journal file, once rotated files are all
+// compressed.
+func (j *journal) Close() error {
+	j.compressing.Wait()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.file == nil {
+		return nil
+	}
+	return j.file.Close()
+}
+
+// compressFile replaces name with a gzip'ed name.gz.
+func compressFile(name string) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(name+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err = io.Copy(zw, src); err == nil {
+		err = zw.Close()
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(name + ".gz")
+		return err
+	}
+	return os.Remove(name)
+}
+
+// journalFiles returns the files of a journal, oldest first.
+func journalFiles(dir string) ([]string, error) {
+	names, err := filepath.Glob(filepath.Join(dir, journalPrefix+"*.jsonl*"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	current := filepath.Join(dir, journalCurrent)
+	if _, err = os.Stat(current); err == nil {
+		names = append(names, current)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%s is not a journal, no journal files found", dir)
+	}
+	return names, nil
+}
+
+// readJournal calls fn with every entry recorded between from and to,
+// zero times are unbounded, in the order they were recorded.
+func readJournal(dir string, from, to time.Time, fn func(journalEntry) error) error {
+	names, err := journalFiles(dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err = readJournalFile(name, from, to, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readJournalFile(name string, from, to time.Time, fn func(journalEntry) error) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(name, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var entry journalEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A crash may leave the last line of a file truncated.
+			fmt.Printf("Skipping malformed journal entry %s:%d. %s\n", name, line, err)
+			continue
+		}
+		if !from.IsZero() && entry.Time.Before(from) {
+			continue
+		}
+		if !to.IsZero() && entry.Time.After(to) {
+			continue
+		}
+		if err = fn(entry); err != nil {
+			return err
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("%s: %s", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/minio/minio-go/v6"
+)
+
+// s3Config - S3 endpoint and credentials minl talks to.
+type s3Config struct {
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Secure    bool
+	Region    string
+}
+
+// newS3ConfigFromEnv - reads S3 configuration from the same
+// environment variables 'minl gen' uses.
+func newS3ConfigFromEnv() s3Config {
+	return s3Config{
+		Endpoint:  os.Getenv("S3_ENDPOINT"),
+		AccessKey: os.Getenv("ACCESS_KEY"),
+		SecretKey: os.Getenv("SECRET_KEY"),
+		Secure:    os.Getenv("S3_SECURE") == "1",
+		Region:    os.Getenv("S3_REGION"),
+	}
+}
+
+// Maximum delay between reconnects of a failing listener.
+const maxListenRetryDelay = 30 * time.Second
+
+// newS3Client - returns a minio-go client for cfg, the region defaults
+// to us-east-1 so that no bucket location is looked up.
+func newS3Client(cfg s3Config) (*minio.Client, error) {
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	return minio.NewWithRegion(cfg.Endpoint, cfg.AccessKey, cfg.SecretKey, cfg.Secure, region)
+}
+
+// listenBucketNotification - listens on bucket notifications until
+// doneCh is closed, the stream is re-established upon failures and
+// every failure is reported on errCh.
+func listenBucketNotification(client *minio.Client, bucket, prefix, suffix string, events []string,
+	doneCh <-chan struct{}, errCh chan<- error) <-chan NotificationInfo {
+	notificationInfoCh := make(chan NotificationInfo, 1)
+	go func() {
+		defer close(notificationInfoCh)
+
+		delay := time.Second
+		for {
+			if listenOnce(client, bucket, prefix, suffix, events, doneCh, errCh, notificationInfoCh) {
+				delay = time.Second
+			}
+			select {
+			case <-doneCh:
+				return
+			case <-time.After(delay):
+			}
+			if delay *= 2; delay > maxListenRetryDelay {
+				delay = maxListenRetryDelay
+			}
+		}
+	}()
+	return notificationInfoCh
+}
+
+// listenOnce - forwards notifications of a single minio-go listener
+// till it gives up or doneCh is closed, reports if any was received.
+func listenOnce(client *minio.Client, bucket, prefix, suffix string, events []string,
+	doneCh <-chan struct{}, errCh chan<- error, notificationInfoCh chan<- NotificationInfo) bool {
+	var received bool
+	listenCh := client.ListenBucketNotification(bucket, prefix, suffix, events, doneCh)
+	for {
+		var info minio.NotificationInfo
+		var ok bool
+		select {
+		case info, ok = <-listenCh:
+		case <-doneCh:
+			return received
+		}
+		if !ok {
+			return received
+		}
+		if info.Err != nil {
+			select {
+			case errCh <- info.Err:
+			default:
+			}
+			continue
+		}
+		received = true
+
+		// Events of minio-go are wire compatible with ours.
+		var notificationInfo NotificationInfo
+		data, err := json.Marshal(info.Records)
+		if err == nil {
+			err = json.Unmarshal(data, &notificationInfo.Records)
+		}
+		if err != nil {
+			select {
+			case errCh <- err:
+			default:
+			}
+			continue
+		}
+		select {
+		case notificationInfoCh <- notificationInfo:
+		case <-doneCh:
+			return received
+		}
+	}
+}
@@ -18,7 +18,7 @@ USAGE:
   {{.Name}} {{if .Flags}}[FLAGS] {{end}}COMMAND{{if .Flags}} [COMMAND FLAGS | -h]{{end}} [ARGUMENTS...]
 
 COMMANDS:
-  {{range .Commands}}{{join .Names ", "}}{{ "\t" }}{{.Usage}}
+  {{range .VisibleCommands}}{{join .Names ", "}}{{ "\t" }}{{.Usage}}
   {{end}}{{if .Flags}}
 GLOBAL FLAGS:
   {{range .Flags}}{{.}}
@@ -33,6 +33,14 @@ VERSION:
 func registerApp() *cli.App {
 	// Register all the commands (refer commands.go)
 	registerCmd(genCmd)
+	registerCmd(runCmd)
+	registerCmd(serveCmd)
+	registerCmd(invokeCmd)
+	registerCmd(replayCmd)
+	registerCmd(emulateCmd)
+	registerCmd(profileCmd)
+	registerCmd(sandboxCmd)
+	registerCmd(superviseCmd)
 	registerCmd(versionCmd)
 	
 	// Set up app.
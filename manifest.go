@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/minl/seccomp/seccomp"
+	"gopkg.in/yaml.v2"
+)
+
+// Manifest files looked up in a lambda directory, in this order.
+var manifestFiles = []string{"minl.yaml", "minl.json"}
+
+// Manifest format version written and understood by this minl.
+const manifestVersion = 1
+
+// Manifest is the declarative description of a lambda, it lives in
+// the lambda directory and is read by every minl command so that
+// lambdas are reproducible from source control. Credentials never
+// go into a manifest, they are always taken from the environment.
+type Manifest struct {
+	Version  int               `json:"version" yaml:"version"`
+	Name     string            `json:"name" yaml:"name"`
+	Runtime  string            `json:"runtime" yaml:"runtime"`
+	Triggers []Trigger         `json:"triggers" yaml:"triggers"`
+	Profile  string            `json:"profile,omitempty" yaml:"profile,omitempty"`
+	Limits   LambdaLimits      `json:"limits" yaml:"limits,omitempty"`
+	Env      map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+}
+
+// Trigger selects the bucket notifications a lambda is invoked for,
+// a lambda may have many triggers all invoking the same handler.
+type Trigger struct {
+	Bucket string   `json:"bucket" yaml:"bucket"`
+	Events []string `json:"events" yaml:"events"`
+	Prefix string   `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	Suffix string   `json:"suffix,omitempty" yaml:"suffix,omitempty"`
+}
+
+// LambdaLimits are resource limits applied to the lambda process,
+// zero means unlimited.
+type LambdaLimits struct {
+	MemoryMB   uint64 `json:"memoryMB,omitempty" yaml:"memoryMB,omitempty"`
+	CPUSeconds uint64 `json:"cpuSeconds,omitempty" yaml:"cpuSeconds,omitempty"`
+	OpenFiles  uint64 `json:"openFiles,omitempty" yaml:"openFiles,omitempty"`
+}
+
+// manifestError is a validation failure of a manifest field.
+type manifestError struct {
+	field string
+	msg   string
+}
+
+func (e manifestError) Error() string {
+	return e.field + ": " + e.msg
+}
+
+// Validate checks the manifest, the first problem found is returned.
+func (m Manifest) Validate() error {
+	if m.Version != manifestVersion {
+		if m.Version == 0 {
+			return manifestError{"version", "is required"}
+		}
+		return manifestError{"version", fmt.Sprintf("unsupported version %d, expected %d", m.Version, manifestVersion)}
+	}
+	if m.Name == "" {
+		return manifestError{"name", "is required"}
+	}
+	if strings.ContainsAny(m.Name, `/\`) {
+		return manifestError{"name", fmt.Sprintf("‘%s’ must not contain path separators", m.Name)}
+	}
+	if _, ok := runtimeTemplates[m.Runtime]; !ok {
+		return manifestError{"runtime", fmt.Sprintf("unsupported runtime ‘%s’, supported runtimes are %s", m.Runtime, strings.Join(supportedRuntimes(), ", "))}
+	}
+	if len(m.Triggers) == 0 {
+		return manifestError{"triggers", "at least one trigger is required"}
+	}
+	for i, trigger := range m.Triggers {
+		field := fmt.Sprintf("triggers[%d]", i)
+		if trigger.Bucket == "" {
+			return manifestError{field + ".bucket", "is required"}
+		}
+		if len(trigger.Events) == 0 {
+			return manifestError{field + ".events", "at least one event is required"}
+		}
+		// Events are checked like --events, wildcards included.
+		if _, err := parseEvents(trigger.Events); err != nil {
+			return manifestError{field + ".events", err.Error()}
+		}
+	}
+	for key := range m.Env {
+		if key == "" || strings.Contains(key, "=") {
+			return manifestError{"env", fmt.Sprintf("invalid variable name ‘%s’", key)}
+		}
+	}
+	return nil
+}
+
+// parseManifest decodes a manifest in YAML or JSON depending on the
+// file name, and validates it. Unknown fields are rejected in both and
+// event wildcards are expanded the way --events does.
+func parseManifest(name string, data []byte) (Manifest, error) {
+	var m Manifest
+	var err error
+	if filepath.Ext(name) == ".json" {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&m)
+	} else {
+		err = yaml.UnmarshalStrict(data, &m)
+	}
+	if err != nil {
+		return m, fmt.Errorf("%s: %s", name, err)
+	}
+	if err = m.Validate(); err != nil {
+		return m, fmt.Errorf("%s: %s", name, err)
+	}
+	for i := range m.Triggers {
+		m.Triggers[i].Events, _ = parseEvents(m.Triggers[i].Events)
+	}
+	return m, nil
+}
+
+// loadManifestFile reads and validates a manifest file.
+func loadManifestFile(name string) (Manifest, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return Manifest{}, err
+	}
+	return parseManifest(name, data)
+}
+
+// loadManifest reads the manifest of a lambda directory.
+func loadManifest(lambdaDir string) (Manifest, error) {
+	for _, name := range manifestFiles {
+		m, err := loadManifestFile(path.Join(lambdaDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		return m, err
+	}
+	return Manifest{}, fmt.Errorf("%s is not a minl lambda, none of %s found", lambdaDir, strings.Join(manifestFiles, ", "))
+}
+
+// saveManifest writes the manifest as YAML into the lambda directory.
+func saveManifest(lambdaDir string, m Manifest) error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path.Join(lambdaDir, manifestFiles[0]), data, 0644)
+}
+
+// newLambdaMetaFromManifest combines a manifest with the S3
+// configuration from the environment.
+func newLambdaMetaFromManifest(m Manifest) LambdaMetadata {
+	cfg := newS3ConfigFromEnv()
+	return LambdaMetadata{
+		PackageName: m.Name,
+		Runtime:     m.Runtime,
+		Endpoint:    cfg.Endpoint,
+		AccessKey:   cfg.AccessKey,
+		SecretKey:   cfg.SecretKey,
+		Secure:      cfg.Secure,
+		Region:      cfg.Region,
+		Triggers:    m.Triggers,
+		Profile:     m.Profile,
+		Limits:      m.Limits,
+		Env:         m.Env,
+	}
+}
+
+// Manifest returns the manifest describing the lambda.
+func (lmeta LambdaMetadata) Manifest() Manifest {
+	return Manifest{
+		Version:  manifestVersion,
+		Name:     lmeta.PackageName,
+		Runtime:  lmeta.Runtime,
+		Triggers: lmeta.Triggers,
+		Profile:  lmeta.Profile,
+		Limits:   lmeta.Limits,
+		Env:      lmeta.Env,
+	}
+}
+
+// lambdaEnv returns the environment a lambda process runs with.
+func lambdaEnv(lmeta LambdaMetadata) []string {
+	env := os.Environ()
+	for key, value := range lmeta.Env {
+		env = append(env, key+"="+value)
+	}
+	return env
+}
+
+// lambdaProfile resolves the lambda's seccomp profile relative to the
+// lambda directory, or to a default profile when the lambda has no such
+// file, empty if it has none.
+func lambdaProfile(lambdaDir string, lmeta LambdaMetadata) string {
+	if lmeta.Profile == "" || filepath.IsAbs(lmeta.Profile) {
+		return lmeta.Profile
+	}
+	profile := filepath.Join(lambdaDir, lmeta.Profile)
+	if _, err := os.Stat(profile); os.IsNotExist(err) {
+		if _, _, err = seccomp.DefaultProfile(lmeta.Profile); err == nil {
+			return lmeta.Profile
+		}
+	}
+	return profile
+}
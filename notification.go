@@ -0,0 +1,135 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Notification event types below are wire compatible with the ones
+// in minio-go, so that lambdas can decode them with their SDK.
+
+// Identity represents the user id, this is a compliance field.
+type Identity struct {
+	PrincipalID string `json:"principalId"`
+}
+
+// BucketMeta - notification event bucket metadata.
+type BucketMeta struct {
+	Name          string   `json:"name"`
+	OwnerIdentity Identity `json:"ownerIdentity"`
+	ARN           string   `json:"arn"`
+}
+
+// ObjectMeta - notification event object metadata.
+type ObjectMeta struct {
+	Key          string            `json:"key"`
+	Size         int64             `json:"size,omitempty"`
+	ETag         string            `json:"eTag,omitempty"`
+	ContentType  string            `json:"contentType,omitempty"`
+	UserMetadata map[string]string `json:"userMetadata,omitempty"`
+	VersionID    string            `json:"versionId,omitempty"`
+	Sequencer    string            `json:"sequencer"`
+}
+
+// EventMeta - notification event server specific metadata.
+type EventMeta struct {
+	SchemaVersion   string     `json:"s3SchemaVersion"`
+	ConfigurationID string     `json:"configurationId"`
+	Bucket          BucketMeta `json:"bucket"`
+	Object          ObjectMeta `json:"object"`
+}
+
+// SourceInfo represents information on the client that
+// triggered the event notification.
+type SourceInfo struct {
+	Host      string `json:"host"`
+	Port      string `json:"port"`
+	UserAgent string `json:"userAgent"`
+}
+
+// NotificationEvent represents an S3 bucket notification event.
+type NotificationEvent struct {
+	EventVersion      string            `json:"eventVersion"`
+	EventSource       string            `json:"eventSource"`
+	AwsRegion         string            `json:"awsRegion"`
+	EventTime         string            `json:"eventTime"`
+	EventName         string            `json:"eventName"`
+	UserIdentity      Identity          `json:"userIdentity"`
+	RequestParameters map[string]string `json:"requestParameters"`
+	ResponseElements  map[string]string `json:"responseElements"`
+	S3                EventMeta         `json:"s3"`
+	Source            SourceInfo        `json:"source"`
+}
+
+// NotificationInfo - collection of notification events, this is
+// also what is delivered to lambdas as a single line of JSON.
+type NotificationInfo struct {
+	Records []NotificationEvent
+}
+
+// newNotificationEvent builds an event on an object the way MinIO
+// does, the object key is escaped as it is on the wire.
+func newNotificationEvent(eventName, region, principalID, bucket string, object ObjectMeta) NotificationEvent {
+	object.Key = url.QueryEscape(object.Key)
+	return NotificationEvent{
+		EventVersion: "2.0",
+		EventSource:  "minio:s3",
+		AwsRegion:    region,
+		EventTime:    time.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
+		EventName:    eventName,
+		UserIdentity: Identity{PrincipalID: principalID},
+		S3: EventMeta{
+			SchemaVersion:   "1.0",
+			ConfigurationID: "Config",
+			Bucket: BucketMeta{
+				Name:          bucket,
+				OwnerIdentity: Identity{PrincipalID: principalID},
+				ARN:           "arn:aws:s3:::" + bucket,
+			},
+			Object: object,
+		},
+	}
+}
+
+// matchEventType reports if eventName is selected by eventType,
+// which may end with a '*' wildcard.
+func matchEventType(eventType, eventName string) bool {
+	if strings.HasSuffix(eventType, "*") {
+		return strings.HasPrefix(eventName, strings.TrimSuffix(eventType, "*"))
+	}
+	return eventType == eventName
+}
+
+// matchTrigger reports if the event is selected by the trigger's
+// bucket, events, prefix and suffix.
+func matchTrigger(trigger Trigger, event NotificationEvent) bool {
+	if event.S3.Bucket.Name != trigger.Bucket {
+		return false
+	}
+	// Object keys are sent URL encoded.
+	key, err := url.QueryUnescape(event.S3.Object.Key)
+	if err != nil {
+		key = event.S3.Object.Key
+	}
+	if !strings.HasPrefix(key, trigger.Prefix) || !strings.HasSuffix(key, trigger.Suffix) {
+		return false
+	}
+	for _, eventType := range trigger.Events {
+		if matchEventType(eventType, event.EventName) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchNotification reports if the event is selected by any of the
+// lambda's triggers.
+func matchNotification(lmeta LambdaMetadata, event NotificationEvent) bool {
+	for _, trigger := range lmeta.Triggers {
+		if matchTrigger(trigger, event) {
+			return true
+		}
+	}
+	return false
+}
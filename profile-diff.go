@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/minio/cli"
+	"github.com/minio/minl/seccomp/seccomp"
+)
+
+// Compare seccomp profiles.
+var profileDiffCmd = cli.Command{
+	Name:   "diff",
+	Usage:  "Show the rules two seccomp profiles differ in",
+	Action: mainProfileDiff,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "Print changes as JSON.",
+		},
+	},
+	CustomHelpTemplate: `NAME:
+   minl profile {{.Name}} - {{.Usage}}
+
+USAGE:
+   minl profile {{.Name}} [FLAGS] OLD-PROFILE NEW-PROFILE
+
+FLAGS:
+  {{range .Flags}}{{.}}
+  {{end}}
+Rules are compared once base profiles are resolved and argument conditions
+normalized. Exits with status 1 when the profiles differ, like diff(1).
+
+EXAMPLES:
+   1. Review the syscalls a runtime upgrade added to a learned profile.
+      $ minl profile {{.Name}} seccomp.json.orig thumbnailer/seccomp.json
+
+`,
+}
+
+// changeMarks prefix changes in text output.
+var changeMarks = map[seccomp.ChangeKind]string{
+	seccomp.Added:   "+",
+	seccomp.Removed: "-",
+	seccomp.Changed: "~",
+}
+
+func checkProfileDiffSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+}
+
+func mainProfileDiff(ctx *cli.Context) {
+	checkProfileDiffSyntax(ctx)
+
+	var profiles []*seccomp.Seccomp
+	for _, profile := range ctx.Args() {
+		scomp, err := loadSeccompProfile(profile)
+		if err != nil {
+			fmt.Println("Unable to load seccomp profile.", err)
+			os.Exit(1)
+		}
+		profiles = append(profiles, scomp)
+	}
+	changes, err := seccomp.Diff(profiles[0], profiles[1])
+	if err != nil {
+		fmt.Println("Unable to compare seccomp profiles.", err)
+		os.Exit(1)
+	}
+
+	if ctx.Bool("json") {
+		if changes == nil {
+			changes = []seccomp.Change{}
+		}
+		data, err := json.MarshalIndent(changes, "", "\t")
+		if err != nil {
+			fmt.Println("Unable to encode changes.", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+	} else if len(changes) > 0 {
+		fmt.Println("---", ctx.Args()[0])
+		fmt.Println("+++", ctx.Args()[1])
+		for _, change := range changes {
+			subject := change.Subject
+			if change.Syscall != "" {
+				subject = change.Syscall
+				if change.Args != "" {
+					subject += "(" + change.Args + ")"
+				}
+				if change.Subject == "notify" {
+					subject = "notify " + subject
+				}
+			}
+			switch change.Kind {
+			case seccomp.Changed:
+				fmt.Println(changeMarks[change.Kind], subject, change.From, "->", change.To)
+			case seccomp.Added:
+				fmt.Println(changeMarks[change.Kind], subject, change.To)
+			default:
+				fmt.Println(changeMarks[change.Kind], subject, change.From)
+			}
+		}
+	}
+
+	if len(changes) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/minl/seccomp/seccomp"
+)
+
+// Explain the BPF program of seccomp profiles.
+var profileExplainCmd = cli.Command{
+	Name:   "explain",
+	Usage:  "Print the BPF program of a seccomp profile, or what it does to syscalls",
+	Action: mainProfileExplain,
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "syscall",
+			Usage: "Evaluate the program on a syscall NAME[:ARG,...] instead of printing it.",
+		},
+		cli.StringFlag{
+			Name:  "arch",
+			Usage: "Architecture of the evaluated syscalls, the native one by default.",
+		},
+	},
+	CustomHelpTemplate: `NAME:
+   minl profile {{.Name}} - {{.Usage}}
+
+USAGE:
+   minl profile {{.Name}} [FLAGS] PROFILE
+
+FLAGS:
+  {{range .Flags}}{{.}}
+  {{end}}
+The profile is compiled to the BPF program the native backend loads, and
+syscalls are evaluated in process without loading it.
+
+EXAMPLES:
+   1. Print the annotated BPF program of a profile.
+      $ minl profile {{.Name}} thumbnailer/seccomp.json
+
+   2. Check what the profile does to IPv4 and IPv6 TCP sockets.
+      $ minl profile {{.Name}} --syscall socket:2,1,0 --syscall socket:10,1,0 thumbnailer/seccomp.json
+
+`,
+}
+
+// parseSyscallSpec parses NAME[:ARG,...] of --syscall, arguments are
+// integers in any base Go accepts.
+func parseSyscallSpec(spec string) (string, []uint64, error) {
+	name, list := spec, ""
+	if i := strings.IndexByte(spec, ':'); i >= 0 {
+		name, list = spec[:i], spec[i+1:]
+	}
+	var args []uint64
+	if list != "" {
+		for _, field := range strings.Split(list, ",") {
+			arg, err := strconv.ParseUint(strings.TrimSpace(field), 0, 64)
+			if err != nil {
+				n, nerr := strconv.ParseInt(strings.TrimSpace(field), 0, 64)
+				if nerr != nil {
+					return "", nil, fmt.Errorf("invalid argument %s of %s", field, spec)
+				}
+				arg = uint64(n)
+			}
+			args = append(args, arg)
+		}
+	}
+	return name, args, nil
+}
+
+func checkProfileExplainSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+	if arch := ctx.String("arch"); arch != "" {
+		if _, err := seccomp.ParseArch(arch); err != nil {
+			fmt.Println("Invalid --arch.", err)
+			os.Exit(1)
+		}
+	}
+	for _, spec := range ctx.StringSlice("syscall") {
+		if _, _, err := parseSyscallSpec(spec); err != nil {
+			fmt.Println("Invalid --syscall.", err)
+			os.Exit(1)
+		}
+	}
+}
+
+func mainProfileExplain(ctx *cli.Context) {
+	checkProfileExplainSyntax(ctx)
+
+	scomp, err := loadSeccompProfile(ctx.Args()[0])
+	if err != nil {
+		fmt.Println("Unable to load seccomp profile.", err)
+		os.Exit(1)
+	}
+	prog, err := seccomp.Compile(scomp)
+	if err != nil {
+		fmt.Println("Unable to compile seccomp profile.", err)
+		os.Exit(1)
+	}
+
+	specs := ctx.StringSlice("syscall")
+	if len(specs) == 0 {
+		for _, line := range seccomp.Disassemble(prog) {
+			fmt.Println(line)
+		}
+		return
+	}
+
+	arch, _ := seccomp.ParseArch(runtime.GOARCH)
+	if name := ctx.String("arch"); name != "" {
+		arch, _ = seccomp.ParseArch(name)
+	}
+	for _, spec := range specs {
+		name, args, _ := parseSyscallSpec(spec)
+		data, err := seccomp.NewSeccompData(arch, name, args...)
+		if err != nil {
+			fmt.Println("Unable to evaluate syscall.", err)
+			os.Exit(1)
+		}
+		ret, err := seccomp.Evaluate(prog, data)
+		if err != nil {
+			fmt.Println("Unable to evaluate syscall.", err)
+			os.Exit(1)
+		}
+		act, retData := seccomp.ReturnAction(ret)
+		result := act.String()
+		if act == seccomp.Errno || act == seccomp.Trace {
+			result = fmt.Sprintf("%s(%d)", act, retData)
+		}
+		formatted := make([]string, len(args))
+		for i, arg := range args {
+			formatted[i] = strconv.FormatUint(arg, 10)
+		}
+		fmt.Printf("%s(%s) on %s: %s\n", name, strings.Join(formatted, ", "), arch, result)
+	}
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/minl/seccomp/seccomp"
+)
+
+// Learn seccomp profile.
+var profileLearnCmd = cli.Command{
+	Name:   "learn",
+	Usage:  "Learn seccomp profile of a lambda from the syscalls it makes",
+	Action: mainProfileLearn,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output",
+			Usage: "File to write the learned profile to.",
+		},
+		cli.StringFlag{
+			Name:  "event",
+			Usage: "Event to deliver to lambda as JSON, a notification or a single event record, '-' reads stdin.",
+		},
+		cli.StringFlag{
+			Name:  "journal",
+			Usage: "Journal directory recorded by 'minl serve --journal' to replay to lambda.",
+		},
+		cli.StringFlag{
+			Name:  "from",
+			Usage: "Replay notifications recorded since, as RFC3339 time or duration ago like 2h.",
+		},
+		cli.StringFlag{
+			Name:  "to",
+			Usage: "Replay notifications recorded until, as RFC3339 time or duration ago like 1h.",
+		},
+		cli.StringSliceFlag{
+			Name:  "args",
+			Usage: "Restrict a syscall argument to the values seen as SYSCALL:INDEX, may be repeated.",
+		},
+		cli.StringFlag{
+			Name:  "default-action",
+			Usage: "Action of the profile for syscalls that were not seen.",
+			Value: "SCMP_ACT_ERRNO",
+		},
+	},
+	CustomHelpTemplate: `NAME:
+   minl profile {{.Name}} - {{.Usage}}
+
+USAGE:
+   minl profile {{.Name}} [FLAGS] LAMBDA-DIR
+   minl profile {{.Name}} [FLAGS] -- COMMAND [ARGS...]
+
+FLAGS:
+  {{range .Flags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Learn the profile of a lambda handling the notifications of the last day.
+      $ minl profile {{.Name}} --output thumbnailer/seccomp.json --journal /var/lib/minl/journal --from 24h thumbnailer
+
+   2. Learn the profile of a lambda handling an event, restricting the domains of the sockets it opens.
+      $ minl profile {{.Name}} --output thumbnailer/seccomp.json --event event.json --args socket:0 thumbnailer
+
+   3. Learn the profile of a command.
+      $ minl profile {{.Name}} --output convert.json -- convert in.jpg -resize 128x128 out.jpg
+
+`,
+}
+
+// Syscalls 'minl sandbox' makes between loading the profile and exec'ing
+// the lambda, they are part of every learned profile. The profile is
+// loaded on all threads, so besides the warnings written on load this
+// covers what the Go runtime does meanwhile on its other threads:
+// sleeping, allocating, returning memory and preempting goroutines.
+var sandboxSyscalls = []string{
+	"clock_nanosleep",
+	"epoll_pwait",
+	"execve",
+	"exit",
+	"exit_group",
+	"futex",
+	"getpid",
+	"gettid",
+	"madvise",
+	"mmap",
+	"munmap",
+	"nanosleep",
+	"rt_sigprocmask",
+	"rt_sigreturn",
+	"sched_yield",
+	"sigaltstack",
+	"tgkill",
+	"write",
+}
+
+// checkProfileLearnSyntax - validate all the passed arguments
+func checkProfileLearnSyntax(ctx *cli.Context) {
+	if !ctx.Args().Present() || ctx.String("output") == "" {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+	if ctx.String("event") != "" && ctx.String("journal") != "" {
+		fmt.Println("Only one of --event or --journal may be given.")
+		os.Exit(1)
+	}
+	if _, err := seccomp.ConvertStringToAction(ctx.String("default-action")); err != nil {
+		fmt.Println("Invalid --default-action.", err)
+		os.Exit(1)
+	}
+}
+
+// syscallLearner aggregates traced syscalls into a profile.
+type syscallLearner struct {
+	arches   map[seccomp.Arch]bool
+	syscalls map[string]bool
+	// Argument values seen by syscall and index, for the arguments
+	// restricted by --args.
+	args    map[string]map[uint]map[uint64]bool
+	unknown map[string]bool
+}
+
+func newSyscallLearner() *syscallLearner {
+	return &syscallLearner{
+		arches:   make(map[seccomp.Arch]bool),
+		syscalls: make(map[string]bool),
+		args:     make(map[string]map[uint]map[uint64]bool),
+		unknown:  make(map[string]bool),
+	}
+}
+
+// parseArgSpec parses a SYSCALL:INDEX argument of --args.
+func (l *syscallLearner) parseArgSpec(spec string) error {
+	parts := strings.SplitN(spec, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("%s is not SYSCALL:INDEX", spec)
+	}
+	index, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil || index > 5 {
+		return fmt.Errorf("%s is not SYSCALL:INDEX, index must be 0 to 5", spec)
+	}
+	if l.args[parts[0]] == nil {
+		l.args[parts[0]] = make(map[uint]map[uint64]bool)
+	}
+	l.args[parts[0]][uint(index)] = make(map[uint64]bool)
+	return nil
+}
+
+// record is called by the tracer for every syscall entered.
+func (l *syscallLearner) record(audit uint32, nr uint64, args [6]uint64) {
+	arch, name, ok := seccomp.ResolveSyscall(audit, nr)
+	if !ok {
+		l.unknown[fmt.Sprintf("%d on architecture %#x", nr, audit)] = true
+		return
+	}
+	l.arches[arch] = true
+	l.syscalls[name] = true
+	for index, values := range l.args[name] {
+		values[args[index]] = true
+	}
+}
+
+// profile returns the profile allowing the syscalls seen, syscalls with
+// restricted arguments get a rule per combination of values seen.
+func (l *syscallLearner) profile(defaultAction seccomp.Action) *seccomp.Seccomp {
+	scomp := &seccomp.Seccomp{DefaultAction: defaultAction}
+	for arch := range l.arches {
+		scomp.Architectures = append(scomp.Architectures, arch)
+	}
+	sort.Slice(scomp.Architectures, func(i, j int) bool {
+		return scomp.Architectures[i] < scomp.Architectures[j]
+	})
+
+	names := make([]string, 0, len(l.syscalls))
+	for name := range l.syscalls {
+		names = append(names, name)
+	}
+	for _, name := range sandboxSyscalls {
+		if !l.syscalls[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var indexes []uint
+		for index := range l.args[name] {
+			indexes = append(indexes, index)
+		}
+		sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+		// Rules are the cartesian product of the values seen, which
+		// stays small for the flag like arguments worth restricting.
+		rules := [][]*seccomp.Arg{{}}
+		for _, index := range indexes {
+			var values []uint64
+			for value := range l.args[name][index] {
+				values = append(values, value)
+			}
+			sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+			var product [][]*seccomp.Arg
+			for _, rule := range rules {
+				for _, value := range values {
+					arg := &seccomp.Arg{Index: index, Value: value, Op: seccomp.EqualTo}
+					product = append(product, append(append([]*seccomp.Arg{}, rule...), arg))
+				}
+			}
+			rules = product
+		}
+		for _, args := range rules {
+			scomp.Syscalls = append(scomp.Syscalls, &seccomp.Syscall{
+				Name:   name,
+				Action: seccomp.Allow,
+				Args:   args,
+			})
+		}
+	}
+	return scomp
+}
+
+// learnEvents returns the notifications to deliver to the lambda, nil
+// when it runs on its own.
+func learnEvents(ctx *cli.Context, lmeta LambdaMetadata) ([]NotificationInfo, error) {
+	if event := ctx.String("event"); event != "" {
+		notificationInfo, err := readEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		return []NotificationInfo{notificationInfo}, nil
+	}
+	journal := ctx.String("journal")
+	if journal == "" {
+		return nil, nil
+	}
+	from, err := parseReplayTime(ctx.String("from"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --from. %s", err)
+	}
+	to, err := parseReplayTime(ctx.String("to"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --to. %s", err)
+	}
+	var events []NotificationInfo
+	err = readJournal(journal, from, to, func(entry journalEntry) error {
+		for _, event := range entry.NotificationInfo.Records {
+			if matchNotification(lmeta, event) {
+				events = append(events, NotificationInfo{Records: []NotificationEvent{event}})
+			}
+		}
+		return nil
+	})
+	if err == nil && len(events) == 0 {
+		err = fmt.Errorf("no notifications for lambda %s in journal", lmeta.PackageName)
+	}
+	return events, err
+}
+
+// traceCommand runs cmd traced until it exits, termination signals are
+// forwarded to it.
+func traceCommand(tracer *syscallTracer, cmd *exec.Cmd) (string, error) {
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	if err := tracer.start(cmd); err != nil {
+		return "", err
+	}
+	go func() {
+		for sig := range sigCh {
+			cmd.Process.Signal(sig)
+		}
+	}()
+	return tracer.wait()
+}
+
+// traceEvents delivers the notifications to the lambda started traced
+// in dispatch mode, and stops it once done or interrupted.
+func traceEvents(tracer *syscallTracer, cmd *exec.Cmd, lmeta LambdaMetadata, events []NotificationInfo) (string, error) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	proc, err := startHandlerProcessWith(cmd, func() error { return tracer.start(cmd) })
+	if err != nil {
+		return "", err
+	}
+	var delivered, failed int
+loop:
+	for _, notificationInfo := range events {
+		select {
+		case <-sigCh:
+			break loop
+		default:
+		}
+		resp, err := proc.exchange(notificationInfo)
+		if err != nil {
+			fmt.Printf("Unable to deliver to lambda %s. %s\n", lmeta.PackageName, err)
+			break
+		}
+		delivered++
+		if resp.Error != "" {
+			failed++
+			event := notificationInfo.Records[0]
+			fmt.Printf("%s %s/%s failed. %s\n", event.EventName, event.S3.Bucket.Name, event.S3.Object.Key, resp.Error)
+		}
+	}
+	fmt.Printf("Delivered %d notifications to lambda %s, %d failed.\n", delivered, lmeta.PackageName, failed)
+
+	// The lambda exits once its stdin is closed, the tracer reaps it.
+	proc.stdin.Close()
+	return tracer.wait()
+}
+
+// learnLambda traces the lambda in lambdaDir, handling the events of
+// --event or --journal, or running on its own until it exits.
+func learnLambda(ctx *cli.Context, tracer *syscallTracer, lambdaDir string) (string, error) {
+	m, err := loadManifest(lambdaDir)
+	if err != nil {
+		fmt.Println("Unable to load lambda.", err)
+		os.Exit(1)
+	}
+	lmeta := newLambdaMetaFromManifest(m)
+	events, err := learnEvents(ctx, lmeta)
+	if err != nil {
+		fmt.Println("Unable to prepare events.", err)
+		os.Exit(1)
+	}
+	args, err := lambdaCommand(lambdaDir, lmeta)
+	if err != nil {
+		fmt.Println("Unable to prepare lambda.", err)
+		os.Exit(1)
+	}
+
+	// The lambda runs unconfined, no profile exists yet.
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = lambdaDir
+	cmd.Env = lambdaEnv(lmeta)
+	if events != nil {
+		return traceEvents(tracer, cmd, lmeta, events)
+	}
+	return traceCommand(tracer, cmd)
+}
+
+func mainProfileLearn(ctx *cli.Context) {
+	checkProfileLearnSyntax(ctx)
+
+	learner := newSyscallLearner()
+	for _, spec := range ctx.StringSlice("args") {
+		if err := learner.parseArgSpec(spec); err != nil {
+			fmt.Println("Invalid --args.", err)
+			os.Exit(1)
+		}
+	}
+	defaultAction, _ := seccomp.ConvertStringToAction(ctx.String("default-action"))
+	tracer := newSyscallTracer(learner.record)
+
+	// A directory is a lambda, anything else a command.
+	args := ctx.Args()
+	start := time.Now()
+	var failure string
+	var err error
+	if fi, statErr := os.Stat(args[0]); statErr == nil && fi.IsDir() {
+		failure, err = learnLambda(ctx, tracer, args[0])
+	} else {
+		if ctx.String("event") != "" || ctx.String("journal") != "" {
+			fmt.Println("Events can only be delivered to lambdas, not to commands.")
+			os.Exit(1)
+		}
+		failure, err = traceCommand(tracer, exec.Command(args[0], args[1:]...))
+	}
+	if err != nil {
+		fmt.Println("Unable to trace syscalls.", err)
+		os.Exit(1)
+	}
+	if failure != "" {
+		fmt.Printf("Warning: %s %s, the learned profile may be incomplete.\n", args[0], failure)
+	}
+
+	for nr := range learner.unknown {
+		fmt.Printf("Warning: syscall %s is unknown and not part of the profile.\n", nr)
+	}
+	scomp := learner.profile(defaultAction)
+	if err = writeSeccompProfile(ctx.String("output"), scomp); err != nil {
+		fmt.Println("Unable to write profile.", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Learned %d syscalls in %s, profile written to %s.\n", len(learner.syscalls),
+		time.Since(start).Round(time.Millisecond), ctx.String("output"))
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/minio/cli"
+	"github.com/minio/minl/seccomp/seccomp"
+)
+
+// Lint seccomp profiles.
+var profileLintCmd = cli.Command{
+	Name:   "lint",
+	Usage:  "Check seccomp profiles for mistakes and dangerous allowances",
+	Action: mainProfileLint,
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "Print findings as JSON.",
+		},
+		cli.StringFlag{
+			Name:  "fail-on",
+			Usage: "Exit with status 1 on findings of this severity or higher: info, warning or error.",
+			Value: "error",
+		},
+	},
+	CustomHelpTemplate: `NAME:
+   minl profile {{.Name}} - {{.Usage}}
+
+USAGE:
+   minl profile {{.Name}} [FLAGS] PROFILE [PROFILE...]
+
+FLAGS:
+  {{range .Flags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Check the profile of a lambda.
+      $ minl profile {{.Name}} thumbnailer/seccomp.json
+
+   2. Fail a CI build on warnings, printing findings as JSON.
+      $ minl profile {{.Name}} --json --fail-on warning */seccomp.json
+
+`,
+}
+
+// profileFindings are the lint findings of a profile.
+type profileFindings struct {
+	Profile  string            `json:"profile"`
+	Findings []seccomp.Finding `json:"findings"`
+}
+
+func checkProfileLintSyntax(ctx *cli.Context) {
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+	if _, err := seccomp.ParseSeverity(ctx.String("fail-on")); err != nil {
+		fmt.Println("Invalid --fail-on.", err)
+		os.Exit(1)
+	}
+}
+
+func mainProfileLint(ctx *cli.Context) {
+	checkProfileLintSyntax(ctx)
+	failOn, _ := seccomp.ParseSeverity(ctx.String("fail-on"))
+
+	var results []profileFindings
+	for _, profile := range ctx.Args() {
+		scomp, err := loadSeccompProfile(profile)
+		if err != nil {
+			fmt.Println("Unable to load seccomp profile.", err)
+			os.Exit(1)
+		}
+		findings := seccomp.Lint(scomp)
+		if findings == nil {
+			findings = []seccomp.Finding{}
+		}
+		results = append(results, profileFindings{profile, findings})
+	}
+
+	failed := false
+	for _, result := range results {
+		for _, finding := range result.Findings {
+			if finding.Severity >= failOn {
+				failed = true
+			}
+		}
+	}
+
+	if ctx.Bool("json") {
+		data, err := json.MarshalIndent(results, "", "\t")
+		if err != nil {
+			fmt.Println("Unable to encode findings.", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+	} else {
+		for _, result := range results {
+			for _, finding := range result.Findings {
+				if finding.Syscall != "" {
+					fmt.Printf("%s: %s: %s: %s %s\n", result.Profile, finding.Severity,
+						finding.Check, finding.Syscall, finding.Message)
+				} else {
+					fmt.Printf("%s: %s: %s: %s\n", result.Profile, finding.Severity,
+						finding.Check, finding.Message)
+				}
+			}
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/minio/cli"
+	"github.com/minio/minl/seccomp/seccomp"
+)
+
+// List the default seccomp profiles.
+var profileLsCmd = cli.Command{
+	Name:   "ls",
+	Usage:  "List the default seccomp profiles shipped with minl",
+	Action: mainProfileLs,
+	CustomHelpTemplate: `NAME:
+   minl profile {{.Name}} - {{.Usage}}
+
+USAGE:
+   minl profile {{.Name}}
+
+Default profiles are named NAME@VERSION and can be used wherever a profile
+file is, like --profile of minl run or profile of a manifest. NAME alone is
+the latest version. Released versions never change.
+
+EXAMPLES:
+   1. List the default profiles.
+      $ minl profile {{.Name}}
+
+`,
+}
+
+func checkProfileLsSyntax(ctx *cli.Context) {
+	if ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+}
+
+func mainProfileLs(ctx *cli.Context) {
+	checkProfileLsSyntax(ctx)
+
+	for _, ref := range seccomp.DefaultProfiles() {
+		fmt.Println(ref)
+	}
+}
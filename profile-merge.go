@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/minl/seccomp/seccomp"
+)
+
+// Merge seccomp profiles.
+var profileMergeCmd = cli.Command{
+	Name:   "merge",
+	Usage:  "Union seccomp profiles into one",
+	Action: mainProfileMerge,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "Write the merged profile to this file.",
+		},
+		cli.BoolFlag{
+			Name:  "force",
+			Usage: "Write the merged profile despite conflicts, keeping the rule of the first profile.",
+		},
+	},
+	CustomHelpTemplate: `NAME:
+   minl profile {{.Name}} - {{.Usage}}
+
+USAGE:
+   minl profile {{.Name}} [FLAGS] PROFILE PROFILE [PROFILE...]
+
+FLAGS:
+  {{range .Flags}}{{.}}
+  {{end}}
+Profiles with different default actions, or different actions for the same
+syscall and arguments, conflict. Conflicts are reported and nothing is
+written unless --force is given.
+
+EXAMPLES:
+   1. Merge the profiles learned from two sets of events.
+      $ minl profile {{.Name}} --output seccomp.json puts.json deletes.json
+
+`,
+}
+
+func checkProfileMergeSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) < 2 || ctx.String("output") == "" {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+}
+
+func mainProfileMerge(ctx *cli.Context) {
+	checkProfileMergeSyntax(ctx)
+
+	var profiles []*seccomp.Seccomp
+	for _, profile := range ctx.Args() {
+		scomp, err := loadSeccompProfile(profile)
+		if err != nil {
+			fmt.Println("Unable to load seccomp profile.", err)
+			os.Exit(1)
+		}
+		profiles = append(profiles, scomp)
+	}
+	merged, conflicts, err := seccomp.Merge(profiles...)
+	if err != nil {
+		fmt.Println("Unable to merge seccomp profiles.", err)
+		os.Exit(1)
+	}
+
+	for _, conflict := range conflicts {
+		subject := "default action"
+		if conflict.Syscall != "" {
+			subject = conflict.Syscall
+			if conflict.Args != "" {
+				subject += "(" + conflict.Args + ")"
+			}
+		}
+		var actions []string
+		for i, action := range conflict.Actions {
+			if action != "" {
+				actions = append(actions, action+" in "+ctx.Args()[i])
+			}
+		}
+		fmt.Printf("Conflict: %s is %s.\n", subject, strings.Join(actions, ", "))
+	}
+	if len(conflicts) > 0 && !ctx.Bool("force") {
+		fmt.Println("Unable to merge seccomp profiles.", len(conflicts), "conflicts, use --force to keep the rules of the first profile.")
+		os.Exit(1)
+	}
+
+	if err = writeSeccompProfile(ctx.String("output"), merged); err != nil {
+		fmt.Println("Unable to write seccomp profile.", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/minio/cli"
+	"github.com/minio/minl/seccomp/seccomp"
+)
+
+// Export a default seccomp profile.
+var profileShowCmd = cli.Command{
+	Name:   "show",
+	Usage:  "Print a default seccomp profile shipped with minl",
+	Action: mainProfileShow,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "Write the profile to this file instead of printing it.",
+		},
+	},
+	CustomHelpTemplate: `NAME:
+   minl profile {{.Name}} - {{.Usage}}
+
+USAGE:
+   minl profile {{.Name}} [FLAGS] NAME[@VERSION]
+
+FLAGS:
+  {{range .Flags}}{{.}}
+  {{end}}
+Without a version the latest version of the profile is shown.
+
+EXAMPLES:
+   1. Start the profile of a Python lambda from the default one.
+      $ minl profile {{.Name}} --output thumbnailer/seccomp.json python-lambda@v1
+
+`,
+}
+
+func checkProfileShowSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+}
+
+func mainProfileShow(ctx *cli.Context) {
+	checkProfileShowSyntax(ctx)
+
+	data, _, err := seccomp.DefaultProfile(ctx.Args()[0])
+	if err != nil {
+		fmt.Println("Unable to find default seccomp profile.", err)
+		os.Exit(1)
+	}
+	if output := ctx.String("output"); output != "" {
+		if err = ioutil.WriteFile(output, data, 0644); err != nil {
+			fmt.Println("Unable to write seccomp profile.", err)
+			os.Exit(1)
+		}
+		return
+	}
+	os.Stdout.Write(data)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/minio/cli"
+	"github.com/minio/minl/seccomp/seccomp"
+)
+
+// Manage seccomp profiles.
+var profileCmd = cli.Command{
+	Name:  "profile",
+	Usage: "Manage seccomp profiles of lambdas",
+	Subcommands: []cli.Command{
+		profileLearnCmd,
+		profileLintCmd,
+		profileDiffCmd,
+		profileMergeCmd,
+		profileExplainCmd,
+		profileLsCmd,
+		profileShowCmd,
+	},
+}
+
+// writeSeccompProfile writes a seccomp profile in indented JSON.
+func writeSeccompProfile(name string, scomp *seccomp.Seccomp) error {
+	data, err := json.MarshalIndent(scomp, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, append(data, '\n'), 0644)
+}
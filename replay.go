@@ -0,0 +1,215 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/minio/cli"
+)
+
+// Replay journal.
+var replayCmd = cli.Command{
+	Name:   "replay",
+	Usage:  "Replay recorded notifications to lambda",
+	Action: mainReplay,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "journal",
+			Usage: "Journal directory recorded by 'minl serve --journal'.",
+		},
+		cli.StringFlag{
+			Name:  "from",
+			Usage: "Replay notifications recorded since, as RFC3339 time or duration ago like 2h.",
+		},
+		cli.StringFlag{
+			Name:  "to",
+			Usage: "Replay notifications recorded until, as RFC3339 time or duration ago like 1h.",
+		},
+		cli.Float64Flag{
+			Name:  "speed",
+			Usage: "Replay speed relative to the original, 0 replays as fast as possible.",
+			Value: 1,
+		},
+		cli.StringFlag{
+			Name:  "profile",
+			Usage: "Seccomp profile to confine lambda with, overrides the manifest.",
+		},
+		cli.BoolFlag{
+			Name:  "strict",
+			Usage: "Fail to start lambda when its seccomp profile has unknown syscalls, instead of skipping their rules.",
+		},
+		cli.BoolFlag{
+			Name:  "failed",
+			Usage: "Replay only the notifications 'minl serve' gave up delivering to lambda.",
+		},
+	},
+	CustomHelpTemplate: `NAME:
+   minl {{.Name}} - {{.Usage}}
+
+USAGE:
+   minl {{.Name}} [FLAGS] LAMBDA-DIR
+
+FLAGS:
+  {{range .Flags}}{{.}}
+  {{end}}
+EXAMPLES:
+   1. Replay the last hour of notifications ten times faster.
+      $ minl {{.Name}} --journal /var/lib/minl/journal --from 1h --speed 10 thumbnailer
+
+   2. Backfill notifications of a day as fast as possible.
+      $ minl {{.Name}} --journal /var/lib/minl/journal --from 2020-05-01T00:00:00Z --to 2020-05-02T00:00:00Z --speed 0 thumbnailer
+
+   3. Deliver again the notifications the lambda died on, once fixed.
+      $ minl {{.Name}} --journal /var/lib/minl/journal --failed --speed 0 thumbnailer
+
+`,
+}
+
+// checkReplaySyntax - validate all the passed arguments
+func checkReplaySyntax(ctx *cli.Context) {
+	if !ctx.Args().Present() || ctx.String("journal") == "" {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+	if ctx.Float64("speed") < 0 {
+		fmt.Println("Replay speed must not be negative.")
+		os.Exit(1)
+	}
+}
+
+// parseReplayTime parses an RFC3339 time or a duration before now,
+// empty is the zero time.
+func parseReplayTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// errReplayInterrupted stops reading the journal when minl is interrupted.
+var errReplayInterrupted = errors.New("interrupted")
+
+func mainReplay(ctx *cli.Context) {
+	checkReplaySyntax(ctx)
+
+	from, err := parseReplayTime(ctx.String("from"))
+	if err != nil {
+		fmt.Println("Invalid --from.", err)
+		os.Exit(1)
+	}
+	to, err := parseReplayTime(ctx.String("to"))
+	if err != nil {
+		fmt.Println("Invalid --to.", err)
+		os.Exit(1)
+	}
+
+	lambdaDir := ctx.Args().First()
+	m, err := loadManifest(lambdaDir)
+	if err != nil {
+		fmt.Println("Unable to load lambda.", err)
+		os.Exit(1)
+	}
+	lmeta := newLambdaMetaFromManifest(m)
+
+	profile := ctx.String("profile")
+	if profile == "" {
+		profile = lambdaProfile(lambdaDir, lmeta)
+	}
+	if profile != "" {
+		if _, err = loadSeccompProfile(profile); err != nil {
+			fmt.Println("Unable to load seccomp profile.", err)
+			os.Exit(1)
+		}
+	}
+	args, err := lambdaCommand(lambdaDir, lmeta)
+	if err != nil {
+		fmt.Println("Unable to prepare lambda.", err)
+		os.Exit(1)
+	}
+
+	doneCh := make(chan struct{})
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		close(doneCh)
+	}()
+
+	// Notifications go through the same handler 'minl serve' uses, so
+	// the lambda is restarted if it dies during the replay.
+	strict := ctx.Bool("strict")
+	h := newLambdaHandler(lmeta, func() (*exec.Cmd, error) {
+		return sandboxCommand(lambdaDir, profile, strict, lmeta, args)
+	})
+
+	speed := ctx.Float64("speed")
+	var last time.Time
+	onlyFailed := ctx.Bool("failed")
+	var replayed, failed int
+	err = readJournal(ctx.String("journal"), from, to, func(entry journalEntry) error {
+		// Undeliverable notifications were recorded as received too.
+		if (entry.Error != "") != onlyFailed || (onlyFailed && entry.Lambda != lmeta.PackageName) {
+			return nil
+		}
+
+		// Events are filtered per lambda like 'minl serve' does.
+		var notificationInfo NotificationInfo
+		for _, event := range entry.NotificationInfo.Records {
+			if matchNotification(lmeta, event) {
+				notificationInfo.Records = append(notificationInfo.Records, event)
+			}
+		}
+		if len(notificationInfo.Records) == 0 {
+			return nil
+		}
+
+		if speed > 0 && !last.IsZero() {
+			select {
+			case <-time.After(time.Duration(float64(entry.Time.Sub(last)) / speed)):
+			case <-doneCh:
+				return errReplayInterrupted
+			}
+		}
+		last = entry.Time
+
+		for _, event := range notificationInfo.Records {
+			resp, err := h.dispatch(NotificationInfo{Records: []NotificationEvent{event}}, doneCh)
+			if _, ok := err.(*undeliverableError); ok {
+				resp.Error, err = err.Error(), nil
+			}
+			if err != nil {
+				select {
+				case <-doneCh:
+					return errReplayInterrupted
+				default:
+					return err
+				}
+			}
+			replayed++
+			if resp.Error != "" {
+				failed++
+				fmt.Printf("%s %s %s/%s failed. %s\n", entry.Time.Format(time.RFC3339Nano), event.EventName,
+					event.S3.Bucket.Name, event.S3.Object.Key, resp.Error)
+			}
+		}
+		return nil
+	})
+	if h.proc != nil {
+		h.proc.stop()
+	}
+	fmt.Printf("Replayed %d notifications to lambda %s, %d failed.\n", replayed, lmeta.PackageName, failed)
+	if err != nil && err != errReplayInterrupted {
+		fmt.Println("Unable to replay journal.", err)
+		os.Exit(1)
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
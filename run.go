@@ -0,0 +1,284 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/minl/seccomp/seccomp"
+)
+
+// Run lambda.
+var runCmd = cli.Command{
+	Name:   "run",
+	Usage:  "Run lambda inside seccomp sandbox",
+	Action: mainRun,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "profile",
+			Usage: "Seccomp profile to confine lambda with, overrides the manifest.",
+		},
+		cli.BoolFlag{
+			Name:  "restart",
+			Usage: "Restart lambda when it exits with failure.",
+		},
+		cli.BoolFlag{
+			Name:  "strict",
+			Usage: "Fail to start lambda when its seccomp profile has unknown syscalls, instead of skipping their rules.",
+		},
+	},
+	CustomHelpTemplate: `NAME:
+   minl {{.Name}} - {{.Usage}}
+
+USAGE:
+   minl {{.Name}} [FLAGS] LAMBDA-DIR
+
+FLAGS:
+  {{range .Flags}}{{.}}
+  {{end}}
+`,
+}
+
+// Environment variable selecting the seccomp backend of lambdas, the
+// native backend is the only one in builds without cgo.
+const seccompBackendEnv = "MINL_SECCOMP_BACKEND"
+
+// Maximum delay between restarts of a failing lambda.
+const maxRestartDelay = 30 * time.Second
+
+// checkRunSyntax - validate all the passed arguments
+func checkRunSyntax(ctx *cli.Context) {
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+}
+
+// loadSeccompProfile reads a seccomp profile in JSON, or the default
+// profile of that name when there is no such file. Docker and OCI
+// profiles are resolved for the running kernel, lambdas never hold any
+// capabilities.
+func loadSeccompProfile(profile string) (*seccomp.Seccomp, error) {
+	data, err := ioutil.ReadFile(profile)
+	if os.IsNotExist(err) && isDefaultProfile(profile) {
+		data, _, err = seccomp.DefaultProfile(profile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	scomp, err := seccomp.ParseDockerProfile(data, seccomp.DockerOptions{
+		KernelVersion: kernelRelease(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", profile, err)
+	}
+	return scomp, nil
+}
+
+// isDefaultProfile reports if profile names a default profile shipped
+// with minl, like go-lambda@v1, rather than a file. Files win.
+func isDefaultProfile(profile string) bool {
+	if _, err := os.Stat(profile); !os.IsNotExist(err) {
+		return false
+	}
+	_, _, err := seccomp.DefaultProfile(profile)
+	return err == nil
+}
+
+// lambdaCommand returns the command line to start the lambda in
+// lambdaDir, Go lambdas are built when their binary is missing.
+func lambdaCommand(lambdaDir string, lmeta LambdaMetadata) ([]string, error) {
+	switch lmeta.Runtime {
+	case "go":
+		binary, err := filepath.Abs(filepath.Join(lambdaDir, lmeta.PackageName))
+		if err != nil {
+			return nil, err
+		}
+		if _, err = os.Stat(binary); os.IsNotExist(err) {
+			build := exec.Command("go", "build", "-o", binary)
+			build.Dir = lambdaDir
+			build.Env = append(os.Environ(), "GO111MODULE=on", "GOFLAGS=-mod=mod")
+			build.Stdout = os.Stdout
+			build.Stderr = os.Stderr
+			if err = build.Run(); err != nil {
+				return nil, fmt.Errorf("unable to build %s: %s", lambdaDir, err)
+			}
+		}
+		return []string{binary}, nil
+	case "python":
+		return lookPathCommand("python3", "main.py")
+	case "node":
+		return lookPathCommand("node", "main.js")
+	default:
+		return lookPathCommand("sh", "main.sh")
+	}
+}
+
+// lookPathCommand resolves the interpreter to an absolute path, the
+// sandbox execs it directly without any PATH lookup.
+func lookPathCommand(interpreter string, args ...string) ([]string, error) {
+	path, err := exec.LookPath(interpreter)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{path}, args...), nil
+}
+
+// sandboxCommand prepares minl to re-execute itself as the sandbox
+// which confines and then execs the lambda, minl itself stays
+// unconfined to supervise it. An empty profile applies only the
+// resource limits, strict fails profiles with unknown syscalls.
+func sandboxCommand(lambdaDir, profile string, strict bool, lmeta LambdaMetadata, args []string) (*exec.Cmd, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	sandboxArgs := []string{sandboxCmd.Name}
+	if profile != "" {
+		if !isDefaultProfile(profile) {
+			if profile, err = filepath.Abs(profile); err != nil {
+				return nil, err
+			}
+		}
+		sandboxArgs = append(sandboxArgs, "--profile", profile)
+		if backend := os.Getenv(seccompBackendEnv); backend != "" {
+			if _, err = seccomp.ParseBackend(backend); err != nil {
+				return nil, err
+			}
+			sandboxArgs = append(sandboxArgs, "--backend", backend)
+		}
+		if strict {
+			sandboxArgs = append(sandboxArgs, "--strict")
+		}
+	}
+	sandboxArgs = append(sandboxArgs,
+		"--memory-mb", strconv.FormatUint(lmeta.Limits.MemoryMB, 10),
+		"--cpu-seconds", strconv.FormatUint(lmeta.Limits.CPUSeconds, 10),
+		"--open-files", strconv.FormatUint(lmeta.Limits.OpenFiles, 10),
+		"--",
+	)
+	cmd := exec.Command(self, append(sandboxArgs, args...)...)
+	cmd.Dir = lambdaDir
+	cmd.Env = lambdaEnv(lmeta)
+	return cmd, nil
+}
+
+// exitReason describes how a lambda process finished.
+func exitReason(state *os.ProcessState) string {
+	if status, ok := state.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		if killedBySeccomp(status.Signal()) {
+			return "killed by seccomp, the profile does not allow a syscall it needs"
+		}
+		return fmt.Sprintf("killed by signal %s", status.Signal())
+	}
+	return fmt.Sprintf("exited with status %d", state.ExitCode())
+}
+
+// superviseLambda runs the lambda until it exits successfully, or
+// until it fails when restart is not requested. Termination signals
+// received by minl are forwarded to the lambda.
+func superviseLambda(newCmd func() (*exec.Cmd, error), restart bool) error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	delay := time.Second
+	for {
+		cmd, err := newCmd()
+		if err != nil {
+			return err
+		}
+		if err = cmd.Start(); err != nil {
+			return err
+		}
+
+		waitCh := make(chan error, 1)
+		go func() { waitCh <- cmd.Wait() }()
+
+		stopped := false
+		for done := false; !done; {
+			select {
+			case sig := <-sigCh:
+				stopped = true
+				cmd.Process.Signal(sig)
+			case err = <-waitCh:
+				done = true
+			}
+		}
+
+		if err == nil {
+			return nil
+		}
+		if _, ok := err.(*exec.ExitError); !ok {
+			return err
+		}
+		reason := exitReason(cmd.ProcessState)
+		if stopped || !restart {
+			return fmt.Errorf("lambda %s", reason)
+		}
+
+		fmt.Printf("Lambda %s, restarting in %s.\n", reason, delay)
+		select {
+		case <-time.After(delay):
+		case <-sigCh:
+			return fmt.Errorf("lambda %s", reason)
+		}
+		if delay *= 2; delay > maxRestartDelay {
+			delay = maxRestartDelay
+		}
+	}
+}
+
+func mainRun(ctx *cli.Context) {
+	checkRunSyntax(ctx)
+
+	lambdaDir := ctx.Args().First()
+	m, err := loadManifest(lambdaDir)
+	if err != nil {
+		fmt.Println("Unable to load lambda.", err)
+		os.Exit(1)
+	}
+	lmeta := newLambdaMetaFromManifest(m)
+
+	profile := ctx.String("profile")
+	if profile == "" {
+		profile = lambdaProfile(lambdaDir, lmeta)
+	}
+	if profile == "" {
+		fmt.Println("No seccomp profile for lambda, pass --profile or set profile in its manifest.")
+		os.Exit(1)
+	}
+
+	// Validate the profile upfront, the sandbox loads it again.
+	if _, err = loadSeccompProfile(profile); err != nil {
+		fmt.Println("Unable to load seccomp profile.", err)
+		os.Exit(1)
+	}
+
+	args, err := lambdaCommand(lambdaDir, lmeta)
+	if err != nil {
+		fmt.Println("Unable to prepare lambda.", err)
+		os.Exit(1)
+	}
+
+	err = superviseLambda(func() (*exec.Cmd, error) {
+		cmd, err := sandboxCommand(lambdaDir, profile, ctx.Bool("strict"), lmeta, args)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd, nil
+	}, ctx.Bool("restart"))
+	if err != nil {
+		fmt.Println("Unable to run lambda.", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/minio/cli"
+	"github.com/minio/minl/seccomp/seccomp"
+)
+
+// Internal command used by 'minl run' and 'minl serve' to confine a
+// lambda, minl re-executes itself with it so that the seccomp filter
+// and resource limits are only applied to the lambda and never to the
+// supervising minl.
+var sandboxCmd = cli.Command{
+	Name:   "sandbox",
+	Usage:  "Exec a command confined by seccomp profile",
+	Action: mainSandbox,
+	Hidden: true,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "profile",
+			Usage: "Seccomp profile to confine command with.",
+		},
+		cli.StringFlag{
+			Name:  "backend",
+			Usage: "Seccomp backend loading the profile, libseccomp or native.",
+		},
+		cli.BoolFlag{
+			Name:  "strict",
+			Usage: "Fail on unknown syscalls in the profile instead of skipping them.",
+		},
+		cli.Uint64Flag{
+			Name:  "memory-mb",
+			Usage: "Address space limit in MiB, 0 is unlimited.",
+		},
+		cli.Uint64Flag{
+			Name:  "cpu-seconds",
+			Usage: "CPU time limit in seconds, 0 is unlimited.",
+		},
+		cli.Uint64Flag{
+			Name:  "open-files",
+			Usage: "Open files limit, 0 is unlimited.",
+		},
+	},
+}
+
+func mainSandbox(ctx *cli.Context) {
+	args := ctx.Args()
+	if !args.Present() {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+
+	limits := LambdaLimits{
+		MemoryMB:   ctx.Uint64("memory-mb"),
+		CPUSeconds: ctx.Uint64("cpu-seconds"),
+		OpenFiles:  ctx.Uint64("open-files"),
+	}
+	if err := applyLimits(limits); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to apply resource limits.", err)
+		os.Exit(1)
+	}
+
+	if profile := ctx.String("profile"); profile != "" {
+		backend, err := seccomp.ParseBackend(ctx.String("backend"))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to load seccomp profile.", err)
+			os.Exit(1)
+		}
+		scomp, err := loadSeccompProfile(profile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to load seccomp profile.", err)
+			os.Exit(1)
+		}
+
+		strict := ctx.Bool("strict")
+		opts := seccomp.ExecOptions{
+			InitOptions: seccomp.InitOptions{
+				Backend: backend,
+				Strict:  strict,
+			},
+			Loaded: func(result *seccomp.InitResult) {
+				for _, name := range result.Unknown {
+					fmt.Fprintf(os.Stderr, "Warning: syscall %s of %s is unknown, its rule is skipped.\n", name, profile)
+				}
+				if strict {
+					return
+				}
+				var arches []string
+				for arch := range result.Skipped {
+					arches = append(arches, string(arch))
+				}
+				sort.Strings(arches)
+				for _, arch := range arches {
+					fmt.Fprintf(os.Stderr, "Warning: syscalls %s of %s do not exist on %s, their rules are skipped there.\n",
+						strings.Join(result.Skipped[seccomp.Arch(arch)], ", "), profile, arch)
+				}
+			},
+		}
+		if seccomp.NeedsSupervisor(scomp) {
+			conn, err := startSupervisor(profile)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Unable to start seccomp supervisor.", err)
+				os.Exit(1)
+			}
+			defer conn.Close()
+			opts.Notify = func(listener *os.File) error {
+				return sendListener(conn, listener)
+			}
+		}
+
+		// Exec only returns on failure.
+		err = seccomp.Exec(scomp, opts, args[0], args, os.Environ())
+		fmt.Fprintln(os.Stderr, "Unable to exec", args[0], err)
+		os.Exit(1)
+	}
+
+	if err := syscall.Exec(args[0], args, os.Environ()); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to exec", args[0], err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,51 @@
+// +build linux
+
+package main
+
+import (
+	"syscall"
+)
+
+// killedBySeccomp reports if sig is the one seccomp kills processes
+// with.
+func killedBySeccomp(sig syscall.Signal) bool {
+	return sig == syscall.SIGSYS
+}
+
+// applyLimits sets resource limits, they are inherited across exec.
+func applyLimits(limits LambdaLimits) error {
+	rlimits := []struct {
+		resource int
+		value    uint64
+	}{
+		{syscall.RLIMIT_AS, limits.MemoryMB << 20},
+		{syscall.RLIMIT_CPU, limits.CPUSeconds},
+		{syscall.RLIMIT_NOFILE, limits.OpenFiles},
+	}
+	for _, rlimit := range rlimits {
+		if rlimit.value == 0 {
+			continue
+		}
+		if err := syscall.Setrlimit(rlimit.resource, &syscall.Rlimit{Cur: rlimit.value, Max: rlimit.value}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kernelRelease returns the release of the running kernel, empty when
+// it cannot be determined.
+func kernelRelease() string {
+	var uts syscall.Utsname
+	if err := syscall.Uname(&uts); err != nil {
+		return ""
+	}
+	var release []byte
+	for _, c := range uts.Release {
+		if c == 0 {
+			break
+		}
+		release = append(release, byte(c))
+	}
+	return string(release)
+}
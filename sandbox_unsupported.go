@@ -0,0 +1,29 @@
+// +build !linux
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+)
+
+// applyLimits fails when a limit is set, resource limits of lambdas
+// are only applied on linux.
+func applyLimits(limits LambdaLimits) error {
+	if limits != (LambdaLimits{}) {
+		return fmt.Errorf("resource limits are not supported on %s", runtime.GOOS)
+	}
+	return nil
+}
+
+// killedBySeccomp is always false, seccomp is not supported on this
+// platform.
+func killedBySeccomp(sig syscall.Signal) bool {
+	return false
+}
+
+// kernelRelease is unknown on this platform.
+func kernelRelease() string {
+	return ""
+}
@@ -3,9 +3,8 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
+	"runtime"
 	"syscall"
 
 	"github.com/minio/minl/seccomp/seccomp"
@@ -24,17 +23,29 @@ func prctl(option int, arg2, arg3, arg4, arg5 uintptr) (err error) {
 
 func main() {
 	fmt.Println("Validate if seccomp enabled", seccomp.IsEnabled())
-	f, err := os.Open("sample.json")
+	data, ref, err := seccomp.DefaultProfile("go-lambda")
 	if err != nil {
-		fmt.Println("Unable to open sample.json", err)
+		fmt.Println("Unable to find go-lambda", err)
 		return
 	}
-	d := json.NewDecoder(f)
-	scomp := &seccomp.Seccomp{}
-	d.Decode(scomp)
+	scomp, err := seccomp.ParseDockerProfile(data, seccomp.DockerOptions{})
+	if err != nil {
+		fmt.Println("Unable to parse", ref, err)
+		return
+	}
+	// No new privileges is set on the thread loading the filter, which
+	// then syncs both to the other threads of the process.
+	runtime.LockOSThread()
 	if err = prctl(PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
 		//		fmt.Println("Unable to set privileges", err)
 		return
 	}
-	seccomp.InitSeccomp(scomp)
+	result, err := seccomp.InitSeccompWith(scomp, seccomp.InitOptions{Tsync: true})
+	if err != nil {
+		fmt.Println("Unable to initialize seccomp", err)
+		return
+	}
+	for _, name := range result.Unknown {
+		fmt.Println("Skipped unknown syscall", name)
+	}
 }
@@ -0,0 +1,25 @@
+package seccomp
+
+import "fmt"
+
+// Backend is the implementation loading a Seccomp filter into the kernel.
+type Backend string
+
+const (
+	// BackendLibseccomp builds the filter with libseccomp, it requires cgo.
+	BackendLibseccomp Backend = "libseccomp"
+	// BackendNative builds the filter with the pure Go compiler of Compile.
+	BackendNative Backend = "native"
+)
+
+// ParseBackend converts a backend name into a Backend, empty is the
+// DefaultBackend.
+func ParseBackend(name string) (Backend, error) {
+	switch Backend(name) {
+	case "":
+		return DefaultBackend, nil
+	case BackendLibseccomp, BackendNative:
+		return Backend(name), nil
+	}
+	return "", fmt.Errorf("%s is not a seccomp backend, must be %s or %s", name, BackendLibseccomp, BackendNative)
+}
@@ -0,0 +1,76 @@
+package seccomp
+
+// Bases are the profiles Seccomp profiles can extend by name. The
+// built-in bases allow the syscalls language runtimes make, following
+// the groups of sandbox/sandbox.c, on top of "core" which every process
+// needs to start, allocate memory, do I/O on its descriptors and exit.
+var Bases = map[string]*Seccomp{
+	"core": {
+		DefaultAction: Errno,
+		Syscalls: allowSyscalls(
+			// Process lifecycle.
+			"execve", "exit", "exit_group", "rt_sigreturn", "sigreturn",
+			"arch_prctl", "set_tid_address", "set_robust_list", "rseq",
+			"prlimit64", "getrlimit", "ugetrlimit", "uname", "getrandom",
+			// Memory.
+			"brk", "mmap", "mmap2", "munmap", "mprotect", "madvise",
+			// Files and descriptors.
+			"read", "write", "readv", "writev", "pread64", "pwrite64",
+			"open", "openat", "close", "access", "faccessat", "faccessat2",
+			"stat", "stat64", "lstat", "lstat64", "fstat", "fstat64",
+			"newfstatat", "fstatat64", "statx", "lseek", "_llseek",
+			"fcntl", "fcntl64", "ioctl", "dup", "dup2", "dup3", "pipe", "pipe2",
+			"getcwd", "readlink", "readlinkat",
+			// Identity.
+			"getpid", "gettid", "getuid", "getgid", "geteuid", "getegid",
+			"getuid32", "getgid32", "geteuid32", "getegid32",
+			// Signals, threads and time.
+			"rt_sigaction", "rt_sigprocmask", "futex", "sched_yield",
+			"nanosleep", "clock_nanosleep", "clock_gettime", "gettimeofday",
+		),
+	},
+	"shell": {
+		Extends: []string{"core"},
+		Syscalls: allowSyscalls(
+			"getppid", "getpgrp", "setpgid", "sysinfo", "sched_getattr",
+			"clone", "clone3", "fork", "vfork", "wait4", "waitid", "kill",
+			"getdents", "getdents64", "chdir", "fchdir", "umask",
+			"rt_sigsuspend",
+		),
+	},
+	"go": {
+		Extends: []string{"core"},
+		Syscalls: allowSyscalls(
+			"sched_getaffinity", "sigaltstack", "clone", "tgkill",
+			"epoll_create", "epoll_create1", "epoll_ctl", "epoll_wait",
+			"epoll_pwait", "eventfd2", "mincore",
+		),
+	},
+	"python": {
+		Extends: []string{"core"},
+		Syscalls: allowSyscalls(
+			"getppid", "getpgrp", "sysinfo", "sched_getaffinity",
+			"sigaltstack", "clone", "clone3", "wait4",
+			"getdents", "getdents64", "chdir", "fadvise64",
+			"select", "_newselect", "pselect6", "poll", "ppoll",
+		),
+	},
+	"nodejs": {
+		Extends: []string{"core"},
+		Syscalls: allowSyscalls(
+			"setrlimit", "clock_getres", "prctl", "capget", "sysinfo",
+			"sched_getaffinity", "clone", "clone3", "pkey_alloc",
+			"epoll_create", "epoll_create1", "epoll_ctl", "epoll_wait",
+			"epoll_pwait", "eventfd2", "poll", "ppoll", "mremap",
+		),
+	},
+}
+
+// allowSyscalls returns rules allowing the syscalls.
+func allowSyscalls(names ...string) []*Syscall {
+	calls := make([]*Syscall, len(names))
+	for i, name := range names {
+		calls[i] = &Syscall{Name: name, Action: Allow}
+	}
+	return calls
+}
@@ -0,0 +1,389 @@
+package seccomp
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// SockFilter is a classic BPF instruction, laid out like the kernel's
+// struct sock_filter.
+type SockFilter struct {
+	Code uint16
+	Jt   uint8
+	Jf   uint8
+	K    uint32
+}
+
+// BPF instruction classes, modes and operations used by filters.
+const (
+	bpfLD  = 0x00
+	bpfALU = 0x04
+	bpfJMP = 0x05
+	bpfRET = 0x06
+
+	bpfW   = 0x00
+	bpfABS = 0x20
+	bpfK   = 0x00
+
+	bpfAND = 0x50
+	bpfJA  = 0x00
+	bpfJEQ = 0x10
+	bpfJGT = 0x20
+	bpfJGE = 0x30
+)
+
+// Return values of seccomp filters, the low 16 bits are the data of
+// Errno and Trace.
+const (
+	retKillProcess = 0x80000000
+	retKillThread  = 0x00000000
+	retTrap        = 0x00030000
+	retErrno       = 0x00050000
+	retUserNotif   = 0x7fc00000
+	retTrace       = 0x7ff00000
+	retLog         = 0x7ffc0000
+	retAllow       = 0x7fff0000
+	retData        = 0x0000ffff
+)
+
+// Offsets of the fields of struct seccomp_data.
+const (
+	dataNr   = 0
+	dataArch = 4
+	dataArgs = 16
+)
+
+// maxInsns is the longest program the kernel accepts, BPF_MAXINSNS.
+const maxInsns = 4096
+
+// errnoEPERM is returned by Errno and Trace actions without ErrnoRet.
+const errnoEPERM = 1
+
+// x32SyscallBit is set in the numbers of x32 syscalls, which share the
+// audit architecture of amd64.
+const x32SyscallBit = 0x40000000
+
+// archInfo describes how the kernel reports syscalls of an architecture.
+type archInfo struct {
+	audit     uint32 // AUDIT_ARCH_* value of seccomp_data.arch
+	bigEndian bool
+	bits32    bool // only the low 32 bits of arguments are compared
+}
+
+var archInfos = map[Arch]archInfo{
+	"x86":      {0x40000003, false, true},
+	"amd64":    {0xc000003e, false, false},
+	"x32":      {0xc000003e, false, true},
+	"arm":      {0x40000028, false, true},
+	"arm64":    {0xc00000b7, false, false},
+	"mips":     {0x00000008, true, true},
+	"mipsel":   {0x40000008, false, true},
+	"mips64":   {0x80000008, true, false},
+	"mipsel64": {0xc0000008, false, false},
+	"ppc64le":  {0xc0000015, false, false},
+	"s390x":    {0x80000016, true, false},
+	"riscv64":  {0xc00000f3, false, false},
+}
+
+// Compile translates config to a seccomp BPF program, filtering the
+// native architecture and the Architectures of config. Syscalls that
+// do not exist on an architecture are ignored like InitSeccomp does.
+func Compile(config *Seccomp) ([]SockFilter, error) {
+	return compile(config, nativeArch(runtime.GOARCH))
+}
+
+func compile(config *Seccomp, native Arch) ([]SockFilter, error) {
+	if config == nil {
+		return nil, fmt.Errorf("cannot compile Seccomp - nil config passed")
+	}
+	defaultAction, err := actionValue(config.DefaultAction, config.DefaultErrnoRet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default action")
+	}
+
+	// Architectures sharing an audit value are told apart by the
+	// syscall number, which only happens for amd64 and x32.
+	var audits []uint32
+	arches := make(map[uint32][]Arch)
+	for _, arch := range append([]Arch{native}, config.Architectures...) {
+		info, ok := archInfos[arch]
+		if !ok || syscallTables[arch] == nil {
+			return nil, fmt.Errorf("architecture %s is not supported by the native backend", arch)
+		}
+		if inArches(arches[info.audit], arch) {
+			continue
+		}
+		if arches[info.audit] == nil {
+			audits = append(audits, info.audit)
+		}
+		arches[info.audit] = append(arches[info.audit], arch)
+	}
+
+	prog := []SockFilter{stmt(bpfLD|bpfW|bpfABS, dataArch)}
+	for _, audit := range audits {
+		block := []SockFilter{stmt(bpfLD|bpfW|bpfABS, dataNr)}
+		if audit == archInfos["amd64"].audit {
+			kill := []SockFilter{stmt(bpfRET|bpfK, retKillThread)}
+			amd64, x32 := kill, kill
+			if inArches(arches[audit], "amd64") {
+				if amd64, err = archBlock(config, "amd64", defaultAction); err != nil {
+					return nil, err
+				}
+			}
+			if inArches(arches[audit], "x32") {
+				if x32, err = archBlock(config, "x32", defaultAction); err != nil {
+					return nil, err
+				}
+			}
+			block = append(block, guard(bpfJGE, x32SyscallBit, x32)...)
+			block = append(block, amd64...)
+		} else {
+			body, err := archBlock(config, arches[audit][0], defaultAction)
+			if err != nil {
+				return nil, err
+			}
+			block = append(block, body...)
+		}
+		prog = append(prog, guard(bpfJEQ, audit, block)...)
+	}
+	prog = append(prog, stmt(bpfRET|bpfK, retKillThread))
+
+	if len(prog) > maxInsns {
+		return nil, fmt.Errorf("filter of %d instructions is longer than the maximum of %d", len(prog), maxInsns)
+	}
+	return prog, nil
+}
+
+// archBlock compiles the rules of an architecture, it expects the
+// syscall number to be loaded.
+func archBlock(config *Seccomp, arch Arch, defaultAction uint32) ([]SockFilter, error) {
+	table := syscallTables[arch]
+
+	// Rules of the same syscall are tried in the order of the profile.
+	var nrs []int
+	rules := make(map[int][]*Syscall)
+	for _, call := range config.Syscalls {
+		if call == nil {
+			return nil, fmt.Errorf("encountered nil syscall while compiling Seccomp")
+		}
+		if len(call.Name) == 0 {
+			return nil, fmt.Errorf("empty string is not a valid syscall")
+		}
+		nr, ok := table[call.Name]
+		if !ok {
+			continue
+		}
+		if rules[nr] == nil {
+			nrs = append(nrs, nr)
+		}
+		rules[nr] = append(rules[nr], call)
+	}
+
+	var block []SockFilter
+	for _, nr := range nrs {
+		var body []SockFilter
+		for _, call := range rules[nr] {
+			rule, err := ruleBlock(call, arch)
+			if err != nil {
+				return nil, err
+			}
+			body = append(body, rule...)
+		}
+		// Unconditional rules return, there is nothing to fall through.
+		if calls := rules[nr]; len(calls[len(calls)-1].Args) > 0 {
+			body = append(body, stmt(bpfRET|bpfK, defaultAction))
+		}
+		block = append(block, guard(bpfJEQ, uint32(nr), body)...)
+	}
+	return append(block, stmt(bpfRET|bpfK, defaultAction)), nil
+}
+
+// ruleBlock compiles a rule, which returns its action when all argument
+// conditions hold and otherwise continues past the block. Symbolic
+// argument values are those of arch.
+func ruleBlock(call *Syscall, arch Arch) ([]SockFilter, error) {
+	action, err := actionValue(call.Action, call.ErrnoRet)
+	if err != nil {
+		return nil, err
+	}
+	block := []SockFilter{stmt(bpfRET|bpfK, action)}
+	for i := len(call.Args) - 1; i >= 0; i-- {
+		arg, err := call.Args[i].resolve(arch)
+		if err != nil {
+			return nil, fmt.Errorf("syscall %s: %s", call.Name, err)
+		}
+		cond, err := condition(arg, archInfos[arch])
+		if err != nil {
+			return nil, err
+		}
+		code, err := resolve(cond, len(block))
+		if err != nil {
+			return nil, fmt.Errorf("syscall %s: %s", call.Name, err)
+		}
+		block = append(code, block...)
+	}
+	return block, nil
+}
+
+// Jump targets of the instructions of a condition: the next instruction,
+// past the condition when it holds, or past the rule when it does not.
+type target int
+
+const (
+	next target = iota
+	pass
+	fail
+)
+
+type insn struct {
+	SockFilter
+	jt, jf target
+}
+
+// condition compiles an argument comparison, comparing 64-bit arguments
+// a 32-bit word at a time.
+func condition(arg *Arg, info archInfo) ([]insn, error) {
+	if arg == nil {
+		return nil, fmt.Errorf("cannot convert nil to syscall condition")
+	}
+	if arg.Index > 5 {
+		return nil, fmt.Errorf("argument index %d is out of range", arg.Index)
+	}
+	lo, hi := uint32(dataArgs+8*arg.Index), uint32(dataArgs+8*arg.Index+4)
+	if info.bigEndian {
+		lo, hi = hi, lo
+	}
+	value, valueTwo := arg.Value, arg.ValueTwo
+
+	ld := func(off uint32) insn { return insn{SockFilter: stmt(bpfLD|bpfW|bpfABS, off)} }
+	jmp := func(op uint16, k uint32, jt, jf target) insn {
+		return insn{SockFilter{Code: bpfJMP | op | bpfK, K: k}, jt, jf}
+	}
+	and := func(k uint32) insn { return insn{SockFilter: stmt(bpfALU|bpfAND|bpfK, k)} }
+
+	var code []insn
+	switch arg.Op {
+	case EqualTo:
+		if !info.bits32 {
+			code = append(code, ld(hi), jmp(bpfJEQ, uint32(value>>32), next, fail))
+		}
+		code = append(code, ld(lo), jmp(bpfJEQ, uint32(value), next, fail))
+	case NotEqualTo:
+		if !info.bits32 {
+			code = append(code, ld(hi), jmp(bpfJEQ, uint32(value>>32), next, pass))
+		}
+		code = append(code, ld(lo), jmp(bpfJEQ, uint32(value), fail, next))
+	case GreaterThan, GreaterThanOrEqualTo:
+		if !info.bits32 {
+			code = append(code, ld(hi), jmp(bpfJGT, uint32(value>>32), pass, next), jmp(bpfJEQ, uint32(value>>32), next, fail))
+		}
+		op := uint16(bpfJGT)
+		if arg.Op == GreaterThanOrEqualTo {
+			op = bpfJGE
+		}
+		code = append(code, ld(lo), jmp(op, uint32(value), next, fail))
+	case LessThan, LessThanOrEqualTo:
+		if !info.bits32 {
+			code = append(code, ld(hi), jmp(bpfJGT, uint32(value>>32), fail, next), jmp(bpfJEQ, uint32(value>>32), next, pass))
+		}
+		op := uint16(bpfJGE)
+		if arg.Op == LessThanOrEqualTo {
+			op = bpfJGT
+		}
+		code = append(code, ld(lo), jmp(op, uint32(value), fail, next))
+	case MaskEqualTo:
+		if !info.bits32 {
+			code = append(code, ld(hi), and(uint32(value>>32)), jmp(bpfJEQ, uint32(valueTwo>>32), next, fail))
+		}
+		code = append(code, ld(lo), and(uint32(value)), jmp(bpfJEQ, uint32(valueTwo), next, fail))
+	default:
+		return nil, fmt.Errorf("invalid operator, cannot use in rule")
+	}
+	return code, nil
+}
+
+// resolve converts the targets of a condition to jump offsets, failing
+// jumps skip the skip instructions following the condition.
+func resolve(code []insn, skip int) ([]SockFilter, error) {
+	offset := func(i int, t target) (uint8, error) {
+		var off int
+		switch t {
+		case pass:
+			off = len(code) - i - 1
+		case fail:
+			off = len(code) - i - 1 + skip
+		}
+		if off > 0xff {
+			return 0, fmt.Errorf("jump of %d instructions is too long", off)
+		}
+		return uint8(off), nil
+	}
+	prog := make([]SockFilter, len(code))
+	for i, in := range code {
+		prog[i] = in.SockFilter
+		if in.Code&0x07 != bpfJMP {
+			continue
+		}
+		var err error
+		if prog[i].Jt, err = offset(i, in.jt); err != nil {
+			return nil, err
+		}
+		if prog[i].Jf, err = offset(i, in.jf); err != nil {
+			return nil, err
+		}
+	}
+	return prog, nil
+}
+
+// guard runs body when the accumulator compares true to k and skips it
+// otherwise, body must not fall through.
+func guard(op uint16, k uint32, body []SockFilter) []SockFilter {
+	if len(body) <= 0xff {
+		return append([]SockFilter{{Code: bpfJMP | op | bpfK, Jf: uint8(len(body)), K: k}}, body...)
+	}
+	return append([]SockFilter{
+		{Code: bpfJMP | op | bpfK, Jt: 1, K: k},
+		stmt(bpfJMP|bpfJA, uint32(len(body))),
+	}, body...)
+}
+
+func stmt(code uint16, k uint32) SockFilter {
+	return SockFilter{Code: code, K: k}
+}
+
+// actionValue returns the filter return value of an action.
+func actionValue(act Action, errnoRet *uint) (uint32, error) {
+	errno := uint32(errnoEPERM)
+	if errnoRet != nil {
+		errno = uint32(*errnoRet) & retData
+	}
+	switch act {
+	case Kill:
+		return retKillThread, nil
+	case KillProcess:
+		return retKillProcess, nil
+	case Errno:
+		return retErrno | errno, nil
+	case Trap:
+		return retTrap, nil
+	case Allow:
+		return retAllow, nil
+	case Trace:
+		return retTrace | errno, nil
+	case Log:
+		return retLog, nil
+	case UserNotif:
+		return retUserNotif, nil
+	default:
+		return 0, fmt.Errorf("invalid action, cannot use in rule")
+	}
+}
+
+func inArches(arches []Arch, arch Arch) bool {
+	for _, a := range arches {
+		if a == arch {
+			return true
+		}
+	}
+	return false
+}
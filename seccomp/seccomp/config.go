@@ -13,11 +13,19 @@ var operators = map[string]Operator{
 }
 
 var actions = map[string]Action{
-	"SCMP_ACT_KILL":  Kill,
-	"SCMP_ACT_ERRNO": Errno,
-	"SCMP_ACT_TRAP":  Trap,
-	"SCMP_ACT_ALLOW": Allow,
-	"SCMP_ACT_TRACE": Trace,
+	"SCMP_ACT_KILL":         Kill,
+	"SCMP_ACT_KILL_PROCESS": KillProcess,
+	"SCMP_ACT_ERRNO":        Errno,
+	"SCMP_ACT_TRAP":         Trap,
+	"SCMP_ACT_ALLOW":        Allow,
+	"SCMP_ACT_TRACE":        Trace,
+	"SCMP_ACT_LOG":          Log,
+	"SCMP_ACT_NOTIFY":       UserNotif,
+}
+
+// Other names of actions, they are never written.
+var actionAliases = map[string]Action{
+	"SCMP_ACT_KILL_THREAD": KillThread,
 }
 
 var archs = map[string]string{
@@ -32,6 +40,12 @@ var archs = map[string]string{
 	"SCMP_ARCH_MIPSEL":      "mipsel",
 	"SCMP_ARCH_MIPSEL64":    "mipsel64",
 	"SCMP_ARCH_MIPSEL64N32": "mipsel64n32",
+	"SCMP_ARCH_PPC":         "ppc",
+	"SCMP_ARCH_PPC64":       "ppc64",
+	"SCMP_ARCH_PPC64LE":     "ppc64le",
+	"SCMP_ARCH_S390":        "s390",
+	"SCMP_ARCH_S390X":       "s390x",
+	"SCMP_ARCH_RISCV64":     "riscv64",
 }
 
 // ConvertStringToOperator converts a string into a Seccomp comparison operator.
@@ -55,6 +69,9 @@ func ConvertStringToAction(in string) (Action, error) {
 	if act, ok := actions[in]; ok == true {
 		return act, nil
 	}
+	if act, ok := actionAliases[in]; ok == true {
+		return act, nil
+	}
 	return 0, fmt.Errorf("string %s is not a valid action for seccomp", in)
 }
 
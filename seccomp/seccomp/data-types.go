@@ -3,13 +3,23 @@ package seccomp
 // Seccomp represents syscall restrictions
 // By default, only the native architecture of the kernel is allowed to be used
 // for syscalls. Additional architectures can be added by specifying them in
-// Architectures.
+// Architectures. A profile may extend base profiles, removing their rules
+// of the syscalls in Remove, see Resolve.
 type Seccomp struct {
-	DefaultAction Action     `json:"defaultAction"`
-	Architectures []string   `json:"architectures"`
-	Syscalls      []*Syscall `json:"syscalls"`
+	Extends         []string   `json:"extends,omitempty"`
+	DefaultAction   Action     `json:"defaultAction"`
+	DefaultErrnoRet *uint      `json:"defaultErrnoRet,omitempty"`
+	Architectures   []Arch     `json:"architectures"`
+	Syscalls        []*Syscall `json:"syscalls"`
+	Remove          []string   `json:"remove,omitempty"`
+	// Notify is the policy of the Supervisor of UserNotif rules.
+	Notify []*NotifyRule `json:"notify,omitempty"`
 }
 
+// Arch is an architecture filtered by Seccomp, named like Go names it,
+// e.g. "amd64" for SCMP_ARCH_X86_64.
+type Arch string
+
 // Action is taken upon rule match in Seccomp
 type Action int
 
@@ -19,6 +29,12 @@ const (
 	Trap
 	Allow
 	Trace
+	KillProcess
+	Log
+	UserNotif
+
+	// KillThread kills the thread making the syscall, like Kill.
+	KillThread = Kill
 )
 
 // Operator is a comparison operator to be used when matching syscall arguments in Seccomp
@@ -34,17 +50,25 @@ const (
 	MaskEqualTo
 )
 
-// Arg is a rule to match a specific syscall argument in Seccomp
+// Arg is a rule to match a specific syscall argument in Seccomp. Values
+// given by symbolic expressions like "O_WRONLY|O_CREAT" are kept in
+// ValueName and ValueTwoName instead of Value and ValueTwo, and resolved
+// for every architecture filtered when loading, see ParseArgValue.
 type Arg struct {
-	Index    uint     `json:"index"`
-	Value    uint64   `json:"value"`
-	ValueTwo uint64   `json:"value_two"`
-	Op       Operator `json:"op"`
+	Index        uint     `json:"index"`
+	Value        uint64   `json:"value"`
+	ValueTwo     uint64   `json:"value_two"`
+	Op           Operator `json:"op"`
+	ValueName    string   `json:"-"`
+	ValueTwoName string   `json:"-"`
 }
 
-// Syscall is a rule to match a syscall in Seccomp
+// Syscall is a rule to match a syscall in Seccomp, ErrnoRet is the
+// errno returned by Errno actions or the message value passed to the
+// tracer by Trace actions, EPERM when unset.
 type Syscall struct {
-	Name   string `json:"name"`
-	Action Action `json:"action"`
-	Args   []*Arg `json:"args"`
+	Name     string `json:"name"`
+	Action   Action `json:"action"`
+	ErrnoRet *uint  `json:"errnoRet,omitempty"`
+	Args     []*Arg `json:"args"`
 }
@@ -0,0 +1,283 @@
+package seccomp
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// ChangeKind is how a rule differs between two profiles.
+type ChangeKind int
+
+const (
+	Added ChangeKind = iota + 1
+	Removed
+	Changed
+)
+
+var changeKinds = map[ChangeKind]string{
+	Added:   "added",
+	Removed: "removed",
+	Changed: "changed",
+}
+
+func (k ChangeKind) String() string {
+	if name, ok := changeKinds[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// MarshalJSON encodes the kind by its name.
+func (k ChangeKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+// Change is a difference between two profiles. Rules are told apart by
+// Syscall and Args, Subject is "default", "architecture", "syscall" or
+// "notify", From and To are the actions, or the architecture and notify
+// policy added or removed.
+type Change struct {
+	Kind    ChangeKind `json:"kind"`
+	Subject string     `json:"subject"`
+	Syscall string     `json:"syscall,omitempty"`
+	Args    string     `json:"args,omitempty"`
+	From    string     `json:"from,omitempty"`
+	To      string     `json:"to,omitempty"`
+}
+
+// Diff compares two profiles once their bases are resolved. Rules are
+// normalized first: argument conditions are sorted and duplicates
+// dropped, the errno of actions not returning one is ignored and unset
+// errnos are EPERM, so that only differences in what the filters do are
+// reported.
+func Diff(a, b *Seccomp) ([]Change, error) {
+	ra, err := normalizeRules(a)
+	if err != nil {
+		return nil, err
+	}
+	rb, err := normalizeRules(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	if from, to := ra.defaultAction, rb.defaultAction; from != to {
+		changes = append(changes, Change{Kind: Changed, Subject: "default", From: from, To: to})
+	}
+	for _, arch := range ra.arches {
+		if !inArches(rb.arches, arch) {
+			changes = append(changes, Change{Kind: Removed, Subject: "architecture", From: arch.String()})
+		}
+	}
+	for _, arch := range rb.arches {
+		if !inArches(ra.arches, arch) {
+			changes = append(changes, Change{Kind: Added, Subject: "architecture", To: arch.String()})
+		}
+	}
+
+	var rules []Change
+	for key, rule := range ra.rules {
+		other, ok := rb.rules[key]
+		if !ok {
+			rules = append(rules, Change{Kind: Removed, Subject: "syscall", Syscall: rule.name, Args: rule.args, From: rule.action})
+		} else if other.action != rule.action {
+			rules = append(rules, Change{Kind: Changed, Subject: "syscall", Syscall: rule.name, Args: rule.args, From: rule.action, To: other.action})
+		}
+	}
+	for key, rule := range rb.rules {
+		if _, ok := ra.rules[key]; !ok {
+			rules = append(rules, Change{Kind: Added, Subject: "syscall", Syscall: rule.name, Args: rule.args, To: rule.action})
+		}
+	}
+	for policy := range ra.notify {
+		if !rb.notify[policy] {
+			rules = append(rules, Change{Kind: Removed, Subject: "notify", Syscall: policy.name, From: policy.policy})
+		}
+	}
+	for policy := range rb.notify {
+		if !ra.notify[policy] {
+			rules = append(rules, Change{Kind: Added, Subject: "notify", Syscall: policy.name, To: policy.policy})
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Syscall != rules[j].Syscall {
+			return rules[i].Syscall < rules[j].Syscall
+		}
+		if rules[i].Subject != rules[j].Subject {
+			return rules[i].Subject > rules[j].Subject
+		}
+		if rules[i].Args != rules[j].Args {
+			return rules[i].Args < rules[j].Args
+		}
+		return rules[i].From+rules[i].To < rules[j].From+rules[j].To
+	})
+	return append(changes, rules...), nil
+}
+
+// normalRule is a rule of a normalized profile.
+type normalRule struct {
+	name, args, action string
+	call               *Syscall
+}
+
+// normalNotify is a path or address a notify rule allows a syscall.
+type normalNotify struct {
+	name, policy string
+}
+
+// normalProfile is a resolved profile normalized to compare rules, the
+// keys of rules are the syscall and its argument conditions.
+type normalProfile struct {
+	config        *Seccomp
+	defaultAction string
+	arches        []Arch
+	rules         map[string]*normalRule
+	order         []string
+	notify        map[normalNotify]bool
+}
+
+// normalizeRules resolves and normalizes config, the first of rules of
+// the same syscall and arguments wins like it does in filters.
+func normalizeRules(config *Seccomp) (*normalProfile, error) {
+	if config == nil {
+		return nil, fmt.Errorf("encountered nil profile")
+	}
+	config, err := Resolve(config)
+	if err != nil {
+		return nil, err
+	}
+	normal := &normalProfile{
+		config:        config,
+		defaultAction: formatAction(config.DefaultAction, config.DefaultErrnoRet),
+		rules:         make(map[string]*normalRule),
+		notify:        make(map[normalNotify]bool),
+	}
+	for _, arch := range config.Architectures {
+		if !inArches(normal.arches, arch) {
+			normal.arches = append(normal.arches, arch)
+		}
+	}
+	for _, call := range config.Syscalls {
+		if call == nil {
+			return nil, fmt.Errorf("encountered nil syscall")
+		}
+		args := formatArgs(call.Name, call.Args)
+		key := call.Name + "(" + args + ")"
+		if _, ok := normal.rules[key]; ok {
+			continue
+		}
+		normal.rules[key] = &normalRule{
+			name:   call.Name,
+			args:   args,
+			action: formatAction(call.Action, call.ErrnoRet),
+			call:   call,
+		}
+		normal.order = append(normal.order, key)
+	}
+	for _, rule := range config.Notify {
+		if rule == nil {
+			return nil, fmt.Errorf("encountered nil notify rule")
+		}
+		errno := ""
+		if rule.ErrnoRet != nil && *rule.ErrnoRet != errnoEPERM {
+			errno = fmt.Sprintf(" errno %d", *rule.ErrnoRet)
+		}
+		for _, name := range rule.Names {
+			for _, path := range rule.Paths {
+				normal.notify[normalNotify{name, "path " + path + errno}] = true
+			}
+			for _, address := range rule.Addresses {
+				normal.notify[normalNotify{name, "address " + address + errno}] = true
+			}
+		}
+	}
+	return normal, nil
+}
+
+// formatAction names an action with the errno it returns, unless it is
+// the default EPERM.
+func formatAction(act Action, errnoRet *uint) string {
+	if (act == Errno || act == Trace) && errnoRet != nil && *errnoRet != errnoEPERM {
+		return fmt.Sprintf("%s(%d)", act, *errnoRet)
+	}
+	return act.String()
+}
+
+// Comparison operators of formatted argument conditions.
+var operatorSymbols = map[Operator]string{
+	EqualTo:              "==",
+	NotEqualTo:           "!=",
+	GreaterThan:          ">",
+	GreaterThanOrEqualTo: ">=",
+	LessThan:             "<",
+	LessThanOrEqualTo:    "<=",
+}
+
+// formatArgs writes argument conditions of call like "arg0 == 2, arg2 &
+// 0x3 == 0x1", sorted and without duplicates. Values of the arguments
+// profiles write symbolically are named like "arg0 == AF_UNIX".
+func formatArgs(call string, args []*Arg) string {
+	native := nativeArch(runtime.GOARCH)
+	sorted := make([]*Arg, 0, len(args))
+	// Symbolic values are compared by their native value, those not
+	// resolving natively are written as they are.
+	unresolved := make(map[*Arg]bool)
+	for _, arg := range args {
+		if arg == nil {
+			continue
+		}
+		if resolved, err := arg.resolve(native); err == nil {
+			arg = resolved
+		} else {
+			unresolved[arg] = true
+		}
+		sorted = append(sorted, arg)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Index != b.Index {
+			return a.Index < b.Index
+		}
+		if a.Op != b.Op {
+			return a.Op < b.Op
+		}
+		if a.Value != b.Value {
+			return a.Value < b.Value
+		}
+		return a.ValueTwo < b.ValueTwo
+	})
+	var conds []string
+	for _, arg := range sorted {
+		// Names are rendered again from the values so that expressions
+		// of the same value compare alike.
+		named := symbolize(call, &Arg{Index: arg.Index, Value: arg.Value, ValueTwo: arg.ValueTwo, Op: arg.Op}, native)
+		if unresolved[arg] {
+			named = arg
+		}
+		value, valueTwo := fmt.Sprintf("%d", arg.Value), fmt.Sprintf("%#x", arg.ValueTwo)
+		if named.ValueName != "" {
+			value = named.ValueName
+		}
+		if named.ValueTwoName != "" {
+			valueTwo = named.ValueTwoName
+		}
+		var cond string
+		if arg.Op == MaskEqualTo {
+			if named.ValueName == "" {
+				value = fmt.Sprintf("%#x", arg.Value)
+			}
+			cond = fmt.Sprintf("arg%d & %s == %s", arg.Index, value, valueTwo)
+		} else if symbol, ok := operatorSymbols[arg.Op]; ok {
+			cond = fmt.Sprintf("arg%d %s %s", arg.Index, symbol, value)
+		} else {
+			cond = fmt.Sprintf("arg%d %s %s", arg.Index, arg.Op, value)
+		}
+		if len(conds) == 0 || conds[len(conds)-1] != cond {
+			conds = append(conds, cond)
+		}
+	}
+	return strings.Join(conds, ", ")
+}
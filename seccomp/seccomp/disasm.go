@@ -0,0 +1,213 @@
+package seccomp
+
+import (
+	"fmt"
+)
+
+// Mnemonics of the BPF instructions seccomp filters may use.
+var (
+	aluMnemonics = map[uint16]string{
+		bpfADD: "add", bpfSUB: "sub", bpfMUL: "mul", bpfDIV: "div", bpfOR: "or",
+		bpfAND: "and", bpfLSH: "lsh", bpfRSH: "rsh", bpfNEG: "neg", bpfMOD: "mod", bpfXOR: "xor",
+	}
+	jumpMnemonics = map[uint16]string{
+		bpfJA: "ja", bpfJEQ: "jeq", bpfJGT: "jgt", bpfJGE: "jge", bpfJSET: "jset",
+	}
+	jumpSymbols = map[uint16]string{
+		bpfJEQ: "==", bpfJGT: ">", bpfJGE: ">=", bpfJSET: "&",
+	}
+)
+
+// disasmState is what the disassembler knows when an instruction runs:
+// the field of seccomp_data in the accumulator and the architecture.
+type disasmState struct {
+	loaded string
+	arch   Arch
+}
+
+// Disassemble prints prog an instruction per line like tcpdump -d does,
+// annotated with the fields of seccomp_data loaded, the architectures
+// and syscalls compared and the actions returned.
+func Disassemble(prog []SockFilter) []string {
+	// Jumps only go forward, the state of an instruction is known once
+	// all the instructions before it are, those reaching it with
+	// different states leave it unknown.
+	states := make([]*disasmState, len(prog)+256)
+	reach := func(pc int, state disasmState) {
+		if pc >= len(states) {
+			return
+		}
+		if states[pc] == nil {
+			states[pc] = &state
+			return
+		}
+		if states[pc].loaded != state.loaded {
+			states[pc].loaded = ""
+		}
+		if states[pc].arch != state.arch {
+			states[pc].arch = ""
+		}
+	}
+	reach(0, disasmState{})
+
+	lines := make([]string, len(prog))
+	for pc, in := range prog {
+		state := disasmState{}
+		if states[pc] != nil {
+			state = *states[pc]
+		}
+		loaded, arch := state.loaded, state.arch
+		jt, jf := pc+1+int(in.Jt), pc+1+int(in.Jf)
+		archT, archF := arch, arch
+		var text, comment string
+		switch {
+		case in.Code == bpfLD|bpfW|bpfABS:
+			text = fmt.Sprintf("ld [%d]", in.K)
+			loaded = dataField(in.K, arch)
+			comment = "A = " + loaded
+		case in.Code == bpfLD|bpfW|bpfLEN, in.Code == bpfLDX|bpfW|bpfLEN:
+			text, loaded = fmt.Sprintf("%s #len", loadMnemonic(in.Code)), ""
+		case in.Code == bpfLD|bpfIMM, in.Code == bpfLDX|bpfIMM:
+			text, loaded = fmt.Sprintf("%s #%#x", loadMnemonic(in.Code), in.K), ""
+		case in.Code == bpfLD|bpfMEM, in.Code == bpfLDX|bpfMEM:
+			text, loaded = fmt.Sprintf("%s M[%d]", loadMnemonic(in.Code), in.K), ""
+		case in.Code == bpfST:
+			text = fmt.Sprintf("st M[%d]", in.K)
+		case in.Code == bpfSTX:
+			text = fmt.Sprintf("stx M[%d]", in.K)
+		case in.Code == bpfMISC|bpfTAX:
+			text = "tax"
+		case in.Code == bpfMISC|bpfTXA:
+			text, loaded = "txa", ""
+		case in.Code == bpfRET|bpfK:
+			text = fmt.Sprintf("ret #%#x", in.K)
+			act, data := ReturnAction(in.K)
+			comment = act.String()
+			if act == Errno || act == Trace {
+				comment = fmt.Sprintf("%s(%d)", act, data)
+			}
+		case in.Code == bpfRET|bpfA:
+			text = "ret a"
+		case in.Code&0x07 == bpfALU:
+			mnemonic, ok := aluMnemonics[in.Code&0xf0]
+			if !ok {
+				text = fmt.Sprintf("invalid %#x", in.Code)
+			} else if in.Code&0xf0 == bpfNEG {
+				text = mnemonic
+			} else if in.Code&bpfX != 0 {
+				text = mnemonic + " x"
+			} else {
+				text = fmt.Sprintf("%s #%#x", mnemonic, in.K)
+			}
+			if in.Code == bpfALU|bpfAND|bpfK && loaded != "" {
+				loaded = fmt.Sprintf("%s & %#x", loaded, in.K)
+				comment = "A = " + loaded
+			} else {
+				loaded = ""
+			}
+		case in.Code&0x07 == bpfJMP:
+			op := in.Code & 0xf0
+			mnemonic, ok := jumpMnemonics[op]
+			switch {
+			case !ok:
+				text = fmt.Sprintf("invalid %#x", in.Code)
+			case op == bpfJA:
+				text = fmt.Sprintf("ja %d", pc+1+int(in.K))
+			case in.Code&bpfX != 0:
+				text = fmt.Sprintf("%s x jt %d jf %d", mnemonic, jt, jf)
+			default:
+				text = fmt.Sprintf("%s #%#x jt %d jf %d", mnemonic, in.K, jt, jf)
+				switch {
+				case loaded == "arch" && op == bpfJEQ:
+					comment = fmt.Sprintf("arch == %#x", in.K)
+					if target := auditArch(in.K); target != "" {
+						comment = fmt.Sprintf("arch == %s", target)
+						archT = target
+					}
+				case loaded == "nr" && op == bpfJGE && in.K == x32SyscallBit && arch == "amd64":
+					comment = "x32 syscall"
+					archT = "x32"
+				case loaded == "nr":
+					comment = fmt.Sprintf("nr %s %d", jumpSymbols[op], in.K)
+					if name, ok := syscallName(arch, in.K); ok && op == bpfJEQ {
+						comment = "nr == " + name
+					}
+				case loaded != "":
+					comment = fmt.Sprintf("%s %s %#x", loaded, jumpSymbols[op], in.K)
+				}
+			}
+		default:
+			text = fmt.Sprintf("invalid %#x", in.Code)
+		}
+
+		switch {
+		case in.Code&0x07 == bpfRET:
+		case in.Code == bpfJMP|bpfJA:
+			reach(pc+1+int(in.K), disasmState{loaded, arch})
+		case in.Code&0x07 == bpfJMP:
+			reach(jt, disasmState{loaded, archT})
+			reach(jf, disasmState{loaded, archF})
+		default:
+			reach(pc+1, disasmState{loaded, arch})
+		}
+
+		line := fmt.Sprintf("(%03d) %s", pc, text)
+		if comment != "" {
+			line = fmt.Sprintf("%-40s ; %s", line, comment)
+		}
+		lines[pc] = line
+	}
+	return lines
+}
+
+// syscallName looks up the syscall nr of arch.
+func syscallName(arch Arch, nr uint32) (string, bool) {
+	for name, n := range syscallTables[arch] {
+		if uint32(n) == nr {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func loadMnemonic(code uint16) string {
+	if code&0x07 == bpfLDX {
+		return "ldx"
+	}
+	return "ld"
+}
+
+// dataField names the word of seccomp_data at offset off, arguments are
+// split in words in the byte order of arch.
+func dataField(off uint32, arch Arch) string {
+	switch {
+	case off == dataNr:
+		return "nr"
+	case off == dataArch:
+		return "arch"
+	case off == 8, off == 12:
+		return "instruction_pointer"
+	case off >= dataArgs && off < seccompDataLen:
+		high := (off-dataArgs)%8 != 0
+		if archInfos[arch].bigEndian {
+			high = !high
+		}
+		half := "low"
+		if high {
+			half = "high"
+		}
+		return fmt.Sprintf("args[%d] %s", (off-dataArgs)/8, half)
+	}
+	return fmt.Sprintf("[%d]", off)
+}
+
+// auditArch returns the architecture of an audit value, amd64 rather
+// than x32 which shares it.
+func auditArch(audit uint32) Arch {
+	for arch, info := range archInfos {
+		if info.audit == audit && arch != "x32" {
+			return arch
+		}
+	}
+	return ""
+}
@@ -0,0 +1,300 @@
+package seccomp
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// DockerSeccomp is a seccomp profile in the format used by Docker and
+// the OCI runtime spec, a superset of the format of Seccomp. Profile
+// flags and user notification listeners are not supported and are
+// ignored.
+type DockerSeccomp struct {
+	Extends         []string             `json:"extends,omitempty"`
+	DefaultAction   Action               `json:"defaultAction"`
+	DefaultErrnoRet *uint                `json:"defaultErrnoRet,omitempty"`
+	Architectures   []Arch               `json:"architectures,omitempty"`
+	ArchMap         []DockerArchitecture `json:"archMap,omitempty"`
+	Flags           []string             `json:"flags,omitempty"`
+	Syscalls        []*DockerSyscall     `json:"syscalls"`
+	Remove          []string             `json:"remove,omitempty"`
+	Notify          []*NotifyRule        `json:"notify,omitempty"`
+}
+
+// DockerArchitecture is the architecture filtered by a profile when
+// running on Arch, along with the sub architectures it can run.
+type DockerArchitecture struct {
+	Arch      Arch   `json:"architecture"`
+	SubArches []Arch `json:"subArchitectures"`
+}
+
+// DockerFilter conditions a syscall rule on the architecture, the
+// capabilities of the process and the kernel version.
+type DockerFilter struct {
+	Caps      []string `json:"caps,omitempty"`
+	Arches    []string `json:"arches,omitempty"`
+	MinKernel string   `json:"minKernel,omitempty"`
+}
+
+// DockerSyscall is a rule matching one or more syscalls.
+type DockerSyscall struct {
+	Name     string        `json:"name,omitempty"` // deprecated in favor of Names.
+	Names    []string      `json:"names,omitempty"`
+	Action   Action        `json:"action"`
+	ErrnoRet *uint         `json:"errnoRet,omitempty"`
+	Args     []*DockerArg  `json:"args"`
+	Comment  string        `json:"comment,omitempty"`
+	Includes *DockerFilter `json:"includes,omitempty"`
+	Excludes *DockerFilter `json:"excludes,omitempty"`
+}
+
+// DockerArg is a rule to match a specific syscall argument, profiles
+// written for Seccomp spell ValueTwo as value_two.
+type DockerArg struct {
+	Index          uint     `json:"index"`
+	Value          uint64   `json:"value"`
+	ValueTwo       uint64   `json:"valueTwo,omitempty"`
+	LegacyValueTwo uint64   `json:"value_two,omitempty"`
+	Op             Operator `json:"op"`
+	// Symbolic expressions of Value and ValueTwo, see Arg.
+	ValueName    string `json:"-"`
+	ValueTwoName string `json:"-"`
+}
+
+// DockerOptions select the conditional rules of a Docker profile which
+// apply to the process being confined.
+type DockerOptions struct {
+	// Arch is the Go name of the architecture, runtime.GOARCH when empty.
+	Arch string
+	// Caps are the capabilities of the process, e.g. CAP_SYS_ADMIN.
+	Caps []string
+	// KernelVersion is the running kernel release, e.g. "5.4.0", when
+	// empty rules are never excluded by their minimum kernel.
+	KernelVersion string
+}
+
+// Go architecture names which are not the names of Arch.
+var goArchs = map[string]Arch{
+	"386":      "x86",
+	"mipsle":   "mipsel",
+	"mips64le": "mipsel64",
+}
+
+// nativeArch returns the Arch of a Go architecture name.
+func nativeArch(goarch string) Arch {
+	if arch, ok := goArchs[goarch]; ok {
+		return arch
+	}
+	return Arch(goarch)
+}
+
+// ParseDockerProfile parses a Docker or OCI profile, lowers it to a
+// Seccomp for the process described by opts and resolves the base
+// profiles it extends.
+func ParseDockerProfile(data []byte, opts DockerOptions) (*Seccomp, error) {
+	var profile DockerSeccomp
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, err
+	}
+	config, err := profile.Lower(opts)
+	if err != nil {
+		return nil, err
+	}
+	return Resolve(config)
+}
+
+// Lower resolves the architecture specific and conditional rules of
+// the profile for the process described by opts.
+func (d *DockerSeccomp) Lower(opts DockerOptions) (*Seccomp, error) {
+	if opts.Arch == "" {
+		opts.Arch = runtime.GOARCH
+	}
+	native := nativeArch(opts.Arch)
+
+	config := &Seccomp{
+		Extends:         d.Extends,
+		Remove:          d.Remove,
+		Notify:          d.Notify,
+		DefaultAction:   d.DefaultAction,
+		DefaultErrnoRet: d.DefaultErrnoRet,
+		Architectures:   d.Architectures,
+	}
+	if len(config.Architectures) == 0 {
+		for _, a := range d.ArchMap {
+			if a.Arch == native {
+				config.Architectures = append([]Arch{a.Arch}, a.SubArches...)
+				break
+			}
+		}
+	}
+
+	for i, call := range d.Syscalls {
+		if call == nil {
+			return nil, fmt.Errorf("syscalls[%d]: encountered nil syscall", i)
+		}
+		names := call.Names
+		if call.Name != "" {
+			if len(names) != 0 {
+				return nil, fmt.Errorf("syscalls[%d]: both name and names are specified", i)
+			}
+			names = []string{call.Name}
+		}
+		included, err := call.applies(opts, native)
+		if err != nil {
+			return nil, fmt.Errorf("syscalls[%d]: %s", i, err)
+		}
+		if !included {
+			continue
+		}
+		for _, args := range call.rules() {
+			for _, name := range names {
+				config.Syscalls = append(config.Syscalls, &Syscall{
+					Name:     name,
+					Action:   call.Action,
+					ErrnoRet: call.ErrnoRet,
+					Args:     args,
+				})
+			}
+		}
+	}
+	return config, nil
+}
+
+// applies reports if the rule is included for the process.
+func (call *DockerSyscall) applies(opts DockerOptions, native Arch) (bool, error) {
+	if f := call.Excludes; f != nil {
+		if len(f.Arches) > 0 && matchArch(f.Arches, opts.Arch, native) {
+			return false, nil
+		}
+		for _, c := range f.Caps {
+			if inSlice(opts.Caps, c) {
+				return false, nil
+			}
+		}
+		if f.MinKernel != "" {
+			newer, err := kernelAtLeast(opts.KernelVersion, f.MinKernel)
+			if err != nil {
+				return false, err
+			}
+			if newer {
+				return false, nil
+			}
+		}
+	}
+	if f := call.Includes; f != nil {
+		if len(f.Arches) > 0 && !matchArch(f.Arches, opts.Arch, native) {
+			return false, nil
+		}
+		for _, c := range f.Caps {
+			if !inSlice(opts.Caps, c) {
+				return false, nil
+			}
+		}
+		if f.MinKernel != "" {
+			newer, err := kernelAtLeast(opts.KernelVersion, f.MinKernel)
+			if err != nil {
+				return false, err
+			}
+			if !newer {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// rules returns the argument conditions of every rule the syscall is
+// lowered to. Conditions are ANDed, except that libseccomp cannot match
+// an argument twice in one rule, those are ORed like runc does by
+// adding a rule per condition.
+func (call *DockerSyscall) rules() [][]*Arg {
+	var args []*Arg
+	seen := make(map[uint]bool)
+	duplicates := false
+	for _, a := range call.Args {
+		if a == nil {
+			continue
+		}
+		valueTwo := a.ValueTwo
+		if valueTwo == 0 && a.ValueTwoName == "" {
+			valueTwo = a.LegacyValueTwo
+		}
+		args = append(args, &Arg{
+			Index:        a.Index,
+			Value:        a.Value,
+			ValueTwo:     valueTwo,
+			Op:           a.Op,
+			ValueName:    a.ValueName,
+			ValueTwoName: a.ValueTwoName,
+		})
+		duplicates = duplicates || seen[a.Index]
+		seen[a.Index] = true
+	}
+	if !duplicates {
+		return [][]*Arg{args}
+	}
+	var rules [][]*Arg
+	for _, arg := range args {
+		rules = append(rules, []*Arg{arg})
+	}
+	return rules
+}
+
+// matchArch reports if the architecture is one of arches, which are Go
+// names or the names of Arch.
+func matchArch(arches []string, goarch string, native Arch) bool {
+	return inSlice(arches, goarch) || inSlice(arches, string(native))
+}
+
+func inSlice(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// kernelAtLeast reports if kernel is at least version minimum, an
+// unknown kernel is assumed to be recent enough.
+func kernelAtLeast(kernel, minimum string) (bool, error) {
+	min, err := parseKernelVersion(minimum)
+	if err != nil {
+		return false, fmt.Errorf("invalid minKernel %s", minimum)
+	}
+	if kernel == "" {
+		return true, nil
+	}
+	cur, err := parseKernelVersion(kernel)
+	if err != nil {
+		return false, fmt.Errorf("invalid kernel version %s", kernel)
+	}
+	for i := range min {
+		if cur[i] != min[i] {
+			return cur[i] > min[i], nil
+		}
+	}
+	return true, nil
+}
+
+// parseKernelVersion parses the major and minor version of a kernel
+// release like "5.4.0-42-generic".
+func parseKernelVersion(release string) ([2]int, error) {
+	var version [2]int
+	parts := strings.SplitN(release, ".", 3)
+	if len(parts) < 2 {
+		return version, fmt.Errorf("invalid kernel version %s", release)
+	}
+	for i := range version {
+		digits := strings.TrimRightFunc(parts[i], func(r rune) bool { return r < '0' || r > '9' })
+		n, err := strconv.Atoi(digits)
+		if err != nil {
+			return version, err
+		}
+		version[i] = n
+	}
+	return version, nil
+}
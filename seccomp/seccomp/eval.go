@@ -0,0 +1,276 @@
+package seccomp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// SeccompData is laid out like the kernel's struct seccomp_data, the
+// syscall a filter decides on.
+type SeccompData struct {
+	Nr                 int32
+	Arch               uint32
+	InstructionPointer uint64
+	Args               [6]uint64
+}
+
+// auditArchLE is set in the audit values of little endian architectures.
+const auditArchLE = 0x40000000
+
+// seccompDataLen is the size of struct seccomp_data.
+const seccompDataLen = 64
+
+// NewSeccompData describes the syscall name of arch made with args.
+func NewSeccompData(arch Arch, name string, args ...uint64) (*SeccompData, error) {
+	info, ok := archInfos[arch]
+	if !ok {
+		return nil, fmt.Errorf("architecture %s is not supported by the native backend", arch)
+	}
+	nr, ok := syscallTables[arch][name]
+	if !ok {
+		return nil, fmt.Errorf("syscall %s does not exist on %s", name, arch)
+	}
+	if len(args) > 6 {
+		return nil, fmt.Errorf("syscalls have 6 arguments, not %d", len(args))
+	}
+	data := &SeccompData{Nr: int32(nr), Arch: info.audit}
+	copy(data.Args[:], args)
+	return data, nil
+}
+
+// marshal lays data out in the byte order of its architecture.
+func (d *SeccompData) marshal() []byte {
+	order := binary.ByteOrder(binary.BigEndian)
+	if d.Arch&auditArchLE != 0 {
+		order = binary.LittleEndian
+	}
+	raw := make([]byte, seccompDataLen)
+	order.PutUint32(raw[dataNr:], uint32(d.Nr))
+	order.PutUint32(raw[dataArch:], d.Arch)
+	order.PutUint64(raw[8:], d.InstructionPointer)
+	for i, arg := range d.Args {
+		order.PutUint64(raw[dataArgs+8*i:], arg)
+	}
+	return raw
+}
+
+// BPF instructions the kernel accepts in seccomp filters besides those
+// Compile emits.
+const (
+	bpfLDX  = 0x01
+	bpfST   = 0x02
+	bpfSTX  = 0x03
+	bpfMISC = 0x07
+
+	bpfIMM = 0x00
+	bpfMEM = 0x60
+	bpfLEN = 0x80
+	bpfX   = 0x08
+	bpfA   = 0x10
+
+	bpfADD  = 0x00
+	bpfSUB  = 0x10
+	bpfMUL  = 0x20
+	bpfDIV  = 0x30
+	bpfOR   = 0x40
+	bpfLSH  = 0x60
+	bpfRSH  = 0x70
+	bpfNEG  = 0x80
+	bpfMOD  = 0x90
+	bpfXOR  = 0xa0
+	bpfJSET = 0x40
+
+	bpfTAX = 0x00
+	bpfTXA = 0x80
+
+	bpfMemWords = 16
+)
+
+// Evaluate runs prog on data like the kernel runs filters on syscalls,
+// returning the value prog returns, without loading it. Programs the
+// kernel would refuse to load fail.
+func Evaluate(prog []SockFilter, data *SeccompData) (uint32, error) {
+	if len(prog) == 0 || len(prog) > maxInsns {
+		return 0, fmt.Errorf("filter of %d instructions is not between 1 and %d", len(prog), maxInsns)
+	}
+	raw := data.marshal()
+	order := binary.ByteOrder(binary.BigEndian)
+	if data.Arch&auditArchLE != 0 {
+		order = binary.LittleEndian
+	}
+
+	var a, x uint32
+	var mem [bpfMemWords]uint32
+	for pc := 0; pc < len(prog); pc++ {
+		in := prog[pc]
+		switch in.Code {
+		case bpfLD | bpfW | bpfABS:
+			if in.K%4 != 0 || in.K >= seccompDataLen {
+				return 0, fmt.Errorf("%d: load of offset %d is out of seccomp_data", pc, in.K)
+			}
+			a = order.Uint32(raw[in.K:])
+		case bpfLD | bpfW | bpfLEN:
+			a = seccompDataLen
+		case bpfLDX | bpfW | bpfLEN:
+			x = seccompDataLen
+		case bpfLD | bpfIMM:
+			a = in.K
+		case bpfLDX | bpfIMM:
+			x = in.K
+		case bpfLD | bpfMEM, bpfLDX | bpfMEM, bpfST, bpfSTX:
+			if in.K >= bpfMemWords {
+				return 0, fmt.Errorf("%d: scratch memory word %d is out of range", pc, in.K)
+			}
+			switch in.Code {
+			case bpfLD | bpfMEM:
+				a = mem[in.K]
+			case bpfLDX | bpfMEM:
+				x = mem[in.K]
+			case bpfST:
+				mem[in.K] = a
+			default:
+				mem[in.K] = x
+			}
+		case bpfMISC | bpfTAX:
+			x = a
+		case bpfMISC | bpfTXA:
+			a = x
+		case bpfRET | bpfK:
+			return in.K, nil
+		case bpfRET | bpfA:
+			return a, nil
+		default:
+			var err error
+			next := pc
+			switch in.Code & 0x07 {
+			case bpfALU:
+				if a, err = evalALU(in, a, x); err == errDivideByX {
+					return 0, nil
+				}
+			case bpfJMP:
+				next, err = evalJump(prog, pc, a, x)
+			default:
+				err = fmt.Errorf("invalid instruction %#x", in.Code)
+			}
+			if err != nil {
+				return 0, fmt.Errorf("%d: %s", pc, err)
+			}
+			pc = next
+		}
+	}
+	return 0, fmt.Errorf("filter does not end with a return")
+}
+
+// errDivideByX is returned for divisions by a zero index register, which
+// end the program returning 0 rather than fail it.
+var errDivideByX = errors.New("division by zero index register")
+
+// evalALU runs an arithmetic instruction on the accumulator.
+func evalALU(in SockFilter, a, x uint32) (uint32, error) {
+	operand := in.K
+	if in.Code&bpfX != 0 {
+		operand = x
+	}
+	switch in.Code &^ bpfX {
+	case bpfALU | bpfADD:
+		return a + operand, nil
+	case bpfALU | bpfSUB:
+		return a - operand, nil
+	case bpfALU | bpfMUL:
+		return a * operand, nil
+	case bpfALU | bpfDIV, bpfALU | bpfMOD:
+		if operand == 0 {
+			// The kernel refuses constant divisors of zero, and
+			// programs dividing by a zero index register return 0.
+			if in.Code&bpfX == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			return 0, errDivideByX
+		}
+		if in.Code&^bpfX == bpfALU|bpfDIV {
+			return a / operand, nil
+		}
+		return a % operand, nil
+	case bpfALU | bpfOR:
+		return a | operand, nil
+	case bpfALU | bpfAND:
+		return a & operand, nil
+	case bpfALU | bpfLSH:
+		return a << (operand & 31), nil
+	case bpfALU | bpfRSH:
+		return a >> (operand & 31), nil
+	case bpfALU | bpfNEG:
+		return -a, nil
+	case bpfALU | bpfXOR:
+		return a ^ operand, nil
+	}
+	return 0, fmt.Errorf("invalid instruction %#x", in.Code)
+}
+
+// evalJump returns the instruction preceding the one a jump at pc
+// continues with.
+func evalJump(prog []SockFilter, pc int, a, x uint32) (int, error) {
+	in := prog[pc]
+	operand := in.K
+	if in.Code&bpfX != 0 {
+		operand = x
+	}
+	var off int
+	switch in.Code &^ bpfX {
+	case bpfJMP | bpfJA:
+		// K is compared before it is converted, int wraps it on 32
+		// bit architectures.
+		if uint64(in.K) >= uint64(len(prog)-pc-1) {
+			return 0, fmt.Errorf("jump of %d instructions is out of the filter", in.K)
+		}
+		off = int(in.K)
+	case bpfJMP | bpfJEQ:
+		off = jumpOffset(in, a == operand)
+	case bpfJMP | bpfJGT:
+		off = jumpOffset(in, a > operand)
+	case bpfJMP | bpfJGE:
+		off = jumpOffset(in, a >= operand)
+	case bpfJMP | bpfJSET:
+		off = jumpOffset(in, a&operand != 0)
+	default:
+		return 0, fmt.Errorf("invalid instruction %#x", in.Code)
+	}
+	if pc+1+off >= len(prog) {
+		return 0, fmt.Errorf("jump of %d instructions is out of the filter", off)
+	}
+	return pc + off, nil
+}
+
+func jumpOffset(in SockFilter, cond bool) int {
+	if cond {
+		return int(in.Jt)
+	}
+	return int(in.Jf)
+}
+
+// ReturnAction decodes the value a filter returns into its action and
+// data, the errno of Errno or the message of Trace. Unknown actions kill
+// the process like the kernel does.
+func ReturnAction(ret uint32) (Action, uint16) {
+	data := uint16(ret & retData)
+	switch ret &^ retData {
+	case retKillProcess:
+		return KillProcess, 0
+	case retKillThread:
+		return Kill, 0
+	case retTrap:
+		return Trap, data
+	case retErrno:
+		return Errno, data
+	case retUserNotif:
+		return UserNotif, 0
+	case retTrace:
+		return Trace, data
+	case retLog:
+		return Log, 0
+	case retAllow:
+		return Allow, 0
+	}
+	return KillProcess, 0
+}
@@ -0,0 +1,117 @@
+package seccomp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	eacces := uint(13)
+	config := &Seccomp{
+		DefaultAction: Allow,
+		Architectures: []Arch{"amd64"},
+		Syscalls: []*Syscall{
+			{Name: "mkdir", Action: Errno, ErrnoRet: &eacces},
+			// openat(dirfd, path, O_WRONLY|...) fails with EPERM.
+			{Name: "openat", Action: Errno, Args: []*Arg{{Index: 2, Value: 0x3, ValueTwo: 0x1, Op: MaskEqualTo}}},
+		},
+	}
+	prog, err := Compile(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		arch   Arch
+		args   []uint64
+		action Action
+		errno  uint16
+	}{
+		{"read", "amd64", nil, Allow, 0},
+		{"mkdir", "amd64", nil, Errno, 13},
+		{"openat", "amd64", []uint64{0, 0, 0x41}, Errno, 1},
+		{"openat", "amd64", []uint64{0, 0, 0x42}, Allow, 0},
+		// Architectures missing from the filter are killed.
+		{"read", "mips", nil, Kill, 0},
+	}
+	for _, test := range tests {
+		data, err := NewSeccompData(test.arch, test.name, test.args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ret, err := Evaluate(prog, data)
+		if err != nil {
+			t.Errorf("%s%v on %s: %s", test.name, test.args, test.arch, err)
+			continue
+		}
+		if action, errno := ReturnAction(ret); action != test.action || errno != test.errno {
+			t.Errorf("%s%v on %s is %v(%d), expected %v(%d)", test.name, test.args, test.arch, action, errno, test.action, test.errno)
+		}
+	}
+}
+
+func TestEvaluateInvalid(t *testing.T) {
+	allow := stmt(bpfRET|bpfK, retAllow)
+	tests := []struct {
+		name string
+		prog []SockFilter
+		err  string
+	}{
+		{"load past seccomp_data", []SockFilter{stmt(bpfLD|bpfW|bpfABS, seccompDataLen), allow}, "out of seccomp_data"},
+		{"unaligned load", []SockFilter{stmt(bpfLD|bpfW|bpfABS, 2), allow}, "out of seccomp_data"},
+		{"jump past the end", []SockFilter{stmt(bpfJMP|bpfJA, 1), allow}, "out of the filter"},
+		{"jump wrapping int", []SockFilter{stmt(bpfJMP|bpfJA, 0xffffffff), allow}, "out of the filter"},
+		{"conditional jump past the end", []SockFilter{{Code: bpfJMP | bpfJEQ | bpfK, Jt: 1, Jf: 1}, allow}, "out of the filter"},
+		{"constant division by zero", []SockFilter{stmt(bpfALU|bpfDIV|bpfK, 0), allow}, "division by zero"},
+		{"no return", []SockFilter{stmt(bpfLD|bpfIMM, 0)}, "does not end with a return"},
+		{"empty", nil, "not between"},
+	}
+	data, err := NewSeccompData("amd64", "read")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		if _, err := Evaluate(test.prog, data); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: got error %v, expected %q", test.name, err, test.err)
+		}
+	}
+}
+
+func TestEvaluateDivideByX(t *testing.T) {
+	// Dividing by a zero index register ends the program returning 0,
+	// which kills the thread.
+	prog := []SockFilter{
+		stmt(bpfLDX|bpfIMM, 0),
+		stmt(bpfALU|bpfDIV|bpfX, 0),
+		stmt(bpfRET|bpfK, retAllow),
+	}
+	data, err := NewSeccompData("amd64", "read")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret, err := Evaluate(prog, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action, _ := ReturnAction(ret); action != Kill {
+		t.Errorf("division by zero index register is %v, expected %v", action, Kill)
+	}
+}
+
+func TestDisassemble(t *testing.T) {
+	prog, err := Compile(&Seccomp{
+		DefaultAction: Allow,
+		Architectures: []Arch{"amd64"},
+		Syscalls:      []*Syscall{{Name: "mkdir", Action: Errno}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := strings.Join(Disassemble(prog), "\n")
+	for _, want := range []string{"A = arch", "nr == mkdir", "SCMP_ACT_ERRNO(1)", "SCMP_ACT_ALLOW"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("disassembly lacks %q:\n%s", want, text)
+		}
+	}
+}
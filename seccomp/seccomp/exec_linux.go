@@ -0,0 +1,41 @@
+// +build linux
+
+package seccomp
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+)
+
+// prSetNoNewPrivs is PR_SET_NO_NEW_PRIVS, which package syscall lacks.
+const prSetNoNewPrivs = 0x26
+
+// Exec confines the process with config and execs argv0 in its place,
+// the way Go programs start confined children since they cannot run
+// code between fork and exec: they start themselves, call Exec and the
+// program executed inherits the filter. The no new privileges bit is
+// set, and the filter is loaded on every thread of the process so that
+// none runs unconfined between loading and exec. Exec only returns on
+// failure, leaving the process confined as far as it got.
+func Exec(config *Seccomp, opts ExecOptions, argv0 string, argv, envv []string) error {
+	// The bit is set on the calling thread, which the kernel extends to
+	// the threads it syncs the filter to, and exec must happen there.
+	runtime.LockOSThread()
+
+	if _, _, e1 := syscall.RawSyscall6(syscall.SYS_PRCTL, prSetNoNewPrivs, 1, 0, 0, 0, 0); e1 != 0 {
+		return fmt.Errorf("error setting no new privileges: %s", e1)
+	}
+	opts.Tsync = true
+	result, err := InitSeccompWith(config, opts.InitOptions)
+	if err != nil {
+		return err
+	}
+	if opts.Loaded != nil {
+		opts.Loaded(result)
+	}
+	if err = syscall.Exec(argv0, argv, envv); err != nil {
+		return fmt.Errorf("error executing %s: %s", argv0, err)
+	}
+	return nil
+}
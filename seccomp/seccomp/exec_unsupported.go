@@ -0,0 +1,20 @@
+// +build !linux
+
+package seccomp
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Exec fails with a config, seccomp is not supported, and otherwise
+// execs argv0 unconfined.
+func Exec(config *Seccomp, opts ExecOptions, argv0 string, argv, envv []string) error {
+	if config != nil {
+		return ErrSeccompNotEnabled
+	}
+	if err := syscall.Exec(argv0, argv, envv); err != nil {
+		return fmt.Errorf("error executing %s: %s", argv0, err)
+	}
+	return nil
+}
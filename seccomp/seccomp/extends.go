@@ -0,0 +1,119 @@
+package seccomp
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Resolve merges the base profiles config extends into a profile that
+// no longer extends any. Bases are looked up by name in Bases and may
+// extend others themselves, they are merged in the order they are
+// listed: architectures and rules are unioned, duplicate rules are kept
+// once, and rules of the same syscall and arguments with different
+// actions are a conflict unless config removes or overrides the
+// syscall. Config then removes the base rules of the syscalls listed in
+// Remove and overrides those of every syscall it has rules for. The
+// default action of config wins, otherwise the bases have to agree on
+// theirs.
+func Resolve(config *Seccomp) (*Seccomp, error) {
+	return resolveExtends(config, nil)
+}
+
+// resolveExtends resolves config, extended by the bases named by chain.
+func resolveExtends(config *Seccomp, chain []string) (*Seccomp, error) {
+	if len(config.Extends) == 0 {
+		if len(config.Remove) > 0 {
+			return nil, fmt.Errorf("remove %s without extending a base profile", config.Remove)
+		}
+		resolved := *config
+		return &resolved, nil
+	}
+
+	// Syscalls config removes or overrides, conflicts of the bases on
+	// them do not matter.
+	replaced := make(map[string]bool)
+	for _, name := range config.Remove {
+		replaced[name] = true
+	}
+	for _, call := range config.Syscalls {
+		if call != nil {
+			replaced[call.Name] = true
+		}
+	}
+
+	merged := &Seccomp{}
+	from := make(map[*Syscall]string)
+	for _, name := range config.Extends {
+		for _, c := range chain {
+			if c == name {
+				return nil, fmt.Errorf("base profile %s extends itself through %s",
+					name, strings.Join(append(chain, name), " -> "))
+			}
+		}
+		base, ok := Bases[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown base profile %s", name)
+		}
+		base, err := resolveExtends(base, append(chain, name))
+		if err != nil {
+			return nil, err
+		}
+
+		if merged.DefaultAction == 0 {
+			merged.DefaultAction, merged.DefaultErrnoRet = base.DefaultAction, base.DefaultErrnoRet
+		} else if config.DefaultAction == 0 && base.DefaultAction != 0 &&
+			(base.DefaultAction != merged.DefaultAction || !reflect.DeepEqual(base.DefaultErrnoRet, merged.DefaultErrnoRet)) {
+			return nil, fmt.Errorf("base profiles %s have different default actions %s and %s",
+				config.Extends, merged.DefaultAction, base.DefaultAction)
+		}
+		for _, arch := range base.Architectures {
+			if !inArches(merged.Architectures, arch) {
+				merged.Architectures = append(merged.Architectures, arch)
+			}
+		}
+		merged.Notify = append(merged.Notify, base.Notify...)
+
+	next:
+		for _, call := range base.Syscalls {
+			for _, other := range merged.Syscalls {
+				if other.Name != call.Name || !reflect.DeepEqual(other.Args, call.Args) {
+					continue
+				}
+				if !replaced[call.Name] && (other.Action != call.Action || !reflect.DeepEqual(other.ErrnoRet, call.ErrnoRet)) {
+					return nil, fmt.Errorf("syscall %s is %s in base profile %s and %s in base profile %s",
+						call.Name, other.Action, from[other], call.Action, name)
+				}
+				continue next
+			}
+			merged.Syscalls = append(merged.Syscalls, call)
+			from[call] = name
+		}
+	}
+
+	// Overlay config on the bases.
+	resolved := &Seccomp{
+		DefaultAction:   merged.DefaultAction,
+		DefaultErrnoRet: merged.DefaultErrnoRet,
+		Architectures:   merged.Architectures,
+		Notify:          append(merged.Notify, config.Notify...),
+	}
+	if config.DefaultAction != 0 {
+		resolved.DefaultAction, resolved.DefaultErrnoRet = config.DefaultAction, config.DefaultErrnoRet
+	}
+	if resolved.DefaultAction == 0 {
+		return nil, fmt.Errorf("neither the profile nor its base profiles %s have a default action", config.Extends)
+	}
+	for _, arch := range config.Architectures {
+		if !inArches(resolved.Architectures, arch) {
+			resolved.Architectures = append(resolved.Architectures, arch)
+		}
+	}
+	for _, call := range merged.Syscalls {
+		if !replaced[call.Name] {
+			resolved.Syscalls = append(resolved.Syscalls, call)
+		}
+	}
+	resolved.Syscalls = append(resolved.Syscalls, config.Syscalls...)
+	return resolved, nil
+}
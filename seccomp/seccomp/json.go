@@ -0,0 +1,238 @@
+package seccomp
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+)
+
+// Profiles are written with the constant names of libseccomp, like
+// "SCMP_ACT_ALLOW", older profiles with plain integers are still read.
+
+// String returns the libseccomp name of the action.
+func (a Action) String() string {
+	for name, act := range actions {
+		if act == a {
+			return name
+		}
+	}
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+// MarshalJSON encodes the action by its libseccomp name.
+func (a Action) MarshalJSON() ([]byte, error) {
+	if _, err := ConvertStringToAction(a.String()); err != nil {
+		return nil, fmt.Errorf("%d is not a valid action for seccomp", int(a))
+	}
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON decodes an action from its libseccomp name or integer.
+func (a *Action) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var n int
+		if err = json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("%s is not a valid action for seccomp", data)
+		}
+		if _, err = ConvertStringToAction(Action(n).String()); err != nil {
+			return fmt.Errorf("%d is not a valid action for seccomp", n)
+		}
+		*a = Action(n)
+		return nil
+	}
+	act, err := ConvertStringToAction(name)
+	if err != nil {
+		return err
+	}
+	*a = act
+	return nil
+}
+
+// String returns the libseccomp name of the operator.
+func (o Operator) String() string {
+	for name, op := range operators {
+		if op == o {
+			return name
+		}
+	}
+	return fmt.Sprintf("Operator(%d)", int(o))
+}
+
+// MarshalJSON encodes the operator by its libseccomp name.
+func (o Operator) MarshalJSON() ([]byte, error) {
+	if _, err := ConvertStringToOperator(o.String()); err != nil {
+		return nil, fmt.Errorf("%d is not a valid operator for seccomp", int(o))
+	}
+	return json.Marshal(o.String())
+}
+
+// UnmarshalJSON decodes an operator from its libseccomp name or integer.
+func (o *Operator) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		var n int
+		if err = json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("%s is not a valid operator for seccomp", data)
+		}
+		if _, err = ConvertStringToOperator(Operator(n).String()); err != nil {
+			return fmt.Errorf("%d is not a valid operator for seccomp", n)
+		}
+		*o = Operator(n)
+		return nil
+	}
+	op, err := ConvertStringToOperator(name)
+	if err != nil {
+		return err
+	}
+	*o = op
+	return nil
+}
+
+// String returns the libseccomp name of the architecture.
+func (a Arch) String() string {
+	for name, arch := range archs {
+		if Arch(arch) == a {
+			return name
+		}
+	}
+	return string(a)
+}
+
+// MarshalJSON encodes the architecture by its libseccomp name.
+func (a Arch) MarshalJSON() ([]byte, error) {
+	if _, err := ConvertStringToArch(a.String()); err != nil {
+		return nil, err
+	}
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON decodes an architecture from its libseccomp name, or
+// the name Go has for it.
+func (a *Arch) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("%s is not a valid arch for seccomp", data)
+	}
+	arch, err := ParseArch(name)
+	if err != nil {
+		return err
+	}
+	*a = arch
+	return nil
+}
+
+// ParseArch parses an architecture from its libseccomp name, or the name
+// Go has for it.
+func ParseArch(name string) (Arch, error) {
+	arch, err := ConvertStringToArch(name)
+	if err != nil {
+		// Go names are accepted as they are.
+		if _, err = ConvertStringToArch(Arch(name).String()); err != nil {
+			return "", err
+		}
+		arch = name
+	}
+	return Arch(arch), nil
+}
+
+// jsonArg is how Arg is written, values are integers or symbolic
+// expressions.
+type jsonArg struct {
+	Index    uint        `json:"index"`
+	Value    interface{} `json:"value"`
+	ValueTwo interface{} `json:"value_two"`
+	Op       Operator    `json:"op"`
+}
+
+// MarshalJSON encodes the values of the argument by their symbolic
+// expressions when they have one.
+func (a Arg) MarshalJSON() ([]byte, error) {
+	arg := jsonArg{Index: a.Index, Value: a.Value, ValueTwo: a.ValueTwo, Op: a.Op}
+	if a.ValueName != "" {
+		arg.Value = a.ValueName
+	}
+	if a.ValueTwoName != "" {
+		arg.ValueTwo = a.ValueTwoName
+	}
+	return json.Marshal(arg)
+}
+
+// UnmarshalJSON decodes an argument whose values are integers or
+// symbolic expressions.
+func (a *Arg) UnmarshalJSON(data []byte) error {
+	var arg struct {
+		Index    uint            `json:"index"`
+		Value    json.RawMessage `json:"value"`
+		ValueTwo json.RawMessage `json:"value_two"`
+		Op       Operator        `json:"op"`
+	}
+	if err := json.Unmarshal(data, &arg); err != nil {
+		return err
+	}
+	*a = Arg{Index: arg.Index, Op: arg.Op}
+	var err error
+	if a.Value, a.ValueName, err = argValue(arg.Value); err != nil {
+		return err
+	}
+	a.ValueTwo, a.ValueTwoName, err = argValue(arg.ValueTwo)
+	return err
+}
+
+// UnmarshalJSON decodes an argument of a Docker profile whose values are
+// integers or symbolic expressions.
+func (a *DockerArg) UnmarshalJSON(data []byte) error {
+	var arg struct {
+		Index          uint            `json:"index"`
+		Value          json.RawMessage `json:"value"`
+		ValueTwo       json.RawMessage `json:"valueTwo"`
+		LegacyValueTwo json.RawMessage `json:"value_two"`
+		Op             Operator        `json:"op"`
+	}
+	if err := json.Unmarshal(data, &arg); err != nil {
+		return err
+	}
+	*a = DockerArg{Index: arg.Index, Op: arg.Op}
+	var err error
+	if a.Value, a.ValueName, err = argValue(arg.Value); err != nil {
+		return err
+	}
+	if a.LegacyValueTwo, a.ValueTwoName, err = argValue(arg.LegacyValueTwo); err != nil {
+		return err
+	}
+	var name string
+	if a.ValueTwo, name, err = argValue(arg.ValueTwo); err != nil {
+		return err
+	}
+	if name != "" || a.ValueTwo != 0 {
+		a.ValueTwoName = name
+	}
+	return nil
+}
+
+// MarshalJSON encodes the profile with the values of the arguments
+// compared for equality written symbolically, e.g. the flags of openat,
+// when they are the same on the native architecture and all of the
+// Architectures filtered.
+func (config Seccomp) MarshalJSON() ([]byte, error) {
+	type seccomp Seccomp
+	named := seccomp(config)
+	arches := append([]Arch{nativeArch(runtime.GOARCH)}, config.Architectures...)
+	named.Syscalls = make([]*Syscall, len(config.Syscalls))
+	for i, call := range config.Syscalls {
+		named.Syscalls[i] = call
+		if call == nil {
+			continue
+		}
+		namedCall := *call
+		namedCall.Args = make([]*Arg, len(call.Args))
+		for j, arg := range call.Args {
+			namedCall.Args[j] = arg
+			if arg != nil {
+				namedCall.Args[j] = symbolize(call.Name, arg, arches...)
+			}
+		}
+		named.Syscalls[i] = &namedCall
+	}
+	return json.Marshal(named)
+}
@@ -0,0 +1,321 @@
+package seccomp
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+)
+
+// Severity of a Finding.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+var severities = map[Severity]string{
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+func (s Severity) String() string {
+	return severities[s]
+}
+
+// ParseSeverity converts the name of a severity into a Severity.
+func ParseSeverity(name string) (Severity, error) {
+	for s, n := range severities {
+		if n == name {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("%s is not a severity, must be info, warning or error", name)
+}
+
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Finding is a problem of a profile reported by Lint.
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Check    string   `json:"check"`
+	Syscall  string   `json:"syscall,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// maxErrno is the largest errno, the kernel caps larger ones to it.
+const maxErrno = 4095
+
+// Architectures each architecture can run besides its own, like the
+// archMap of Docker profiles.
+var compatArches = map[Arch][]Arch{
+	"amd64":    {"x86", "x32"},
+	"arm64":    {"arm"},
+	"mips64":   {"mips", "mips64n32"},
+	"mipsel64": {"mipsel", "mipsel64n32"},
+	"ppc64":    {"ppc"},
+	"s390x":    {"s390"},
+}
+
+// dangerousSyscall is a syscall that should not be allowed to sandboxed
+// processes, see "Significant syscalls blocked by the default profile".
+type dangerousSyscall struct {
+	severity Severity
+	reason   string
+}
+
+var dangerousSyscalls = map[string]dangerousSyscall{
+	"acct":              {SeverityWarning, "lets processes disable their own resource limits or process accounting"},
+	"add_key":           {SeverityWarning, "uses the kernel keyring, which is not namespaced"},
+	"adjtimex":          {SeverityWarning, "changes the time, which is not namespaced"},
+	"bpf":               {SeverityError, "loads potentially persistent bpf programs into the kernel"},
+	"clock_adjtime":     {SeverityWarning, "changes the time, which is not namespaced"},
+	"clock_settime":     {SeverityWarning, "changes the time, which is not namespaced"},
+	"clone":             {SeverityWarning, "creates namespaces unless restricted by its flags argument"},
+	"create_module":     {SeverityError, "manipulates kernel modules"},
+	"delete_module":     {SeverityError, "manipulates kernel modules"},
+	"finit_module":      {SeverityError, "manipulates kernel modules"},
+	"get_kernel_syms":   {SeverityWarning, "retrieves exported kernel and module symbols"},
+	"get_mempolicy":     {SeverityWarning, "modifies kernel memory and NUMA settings"},
+	"init_module":       {SeverityError, "manipulates kernel modules"},
+	"ioperm":            {SeverityError, "modifies kernel I/O privilege levels"},
+	"iopl":              {SeverityError, "modifies kernel I/O privilege levels"},
+	"kcmp":              {SeverityWarning, "inspects other processes"},
+	"kexec_file_load":   {SeverityError, "loads a new kernel for later execution"},
+	"kexec_load":        {SeverityError, "loads a new kernel for later execution"},
+	"keyctl":            {SeverityWarning, "uses the kernel keyring, which is not namespaced"},
+	"lookup_dcookie":    {SeverityWarning, "traces and profiles, which could leak information on the host"},
+	"mbind":             {SeverityWarning, "modifies kernel memory and NUMA settings"},
+	"mount":             {SeverityError, "mounts filesystems"},
+	"move_pages":        {SeverityWarning, "modifies kernel memory and NUMA settings"},
+	"name_to_handle_at": {SeverityWarning, "is the sister syscall of open_by_handle_at"},
+	"nfsservctl":        {SeverityWarning, "interacts with the kernel nfs daemon"},
+	"open_by_handle_at": {SeverityError, "caused an old container breakout"},
+	"perf_event_open":   {SeverityWarning, "traces and profiles, which could leak information on the host"},
+	"personality":       {SeverityWarning, "enables BSD emulation unless restricted by its persona argument"},
+	"pivot_root":        {SeverityError, "changes the root filesystem"},
+	"process_vm_readv":  {SeverityWarning, "inspects other processes"},
+	"process_vm_writev": {SeverityError, "modifies other processes"},
+	"ptrace":            {SeverityError, "traces other processes, which could leak information on the host"},
+	"query_module":      {SeverityWarning, "manipulates kernel modules"},
+	"quotactl":          {SeverityWarning, "lets processes disable their own resource limits"},
+	"reboot":            {SeverityError, "reboots the host"},
+	"request_key":       {SeverityWarning, "uses the kernel keyring, which is not namespaced"},
+	"set_mempolicy":     {SeverityWarning, "modifies kernel memory and NUMA settings"},
+	"setns":             {SeverityError, "joins other namespaces"},
+	"settimeofday":      {SeverityWarning, "changes the time, which is not namespaced"},
+	"stime":             {SeverityWarning, "changes the time, which is not namespaced"},
+	"swapoff":           {SeverityError, "stops swapping to files and devices"},
+	"swapon":            {SeverityError, "starts swapping to files and devices"},
+	"sysfs":             {SeverityWarning, "is obsolete"},
+	"_sysctl":           {SeverityWarning, "is obsolete, replaced by /proc/sys"},
+	"umount":            {SeverityError, "unmounts filesystems"},
+	"umount2":           {SeverityError, "unmounts filesystems"},
+	"unshare":           {SeverityError, "creates namespaces"},
+	"uselib":            {SeverityWarning, "is obsolete"},
+	"userfaultfd":       {SeverityWarning, "handles page faults in userspace, a common exploitation aid"},
+	"ustat":             {SeverityWarning, "is obsolete"},
+	"vm86":              {SeverityWarning, "runs an in kernel x86 real mode virtual machine"},
+	"vm86old":           {SeverityWarning, "runs an in kernel x86 real mode virtual machine"},
+}
+
+// Lint checks a profile for mistakes and dangerous allowances on this
+// host, findings are sorted by decreasing severity.
+func Lint(config *Seccomp) []Finding {
+	return lint(config, nativeArch(runtime.GOARCH))
+}
+
+func lint(config *Seccomp, native Arch) []Finding {
+	var findings []Finding
+	report := func(severity Severity, check, syscall, format string, args ...interface{}) {
+		findings = append(findings, Finding{
+			Severity: severity,
+			Check:    check,
+			Syscall:  syscall,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	// Architectures.
+	arches := []Arch{native}
+	for _, arch := range config.Architectures {
+		if arch != native && !inArches(compatArches[native], arch) {
+			report(SeverityWarning, "unsupported-arch", "",
+				"architecture %s cannot run on %s hosts, its rules are never used", arch, native)
+		}
+		if !inArches(arches, arch) {
+			arches = append(arches, arch)
+		}
+	}
+
+	if config.DefaultErrnoRet != nil && config.DefaultAction == Errno && *config.DefaultErrnoRet > maxErrno {
+		report(SeverityWarning, "invalid-errno", "",
+			"default errno %d is out of range, the kernel returns %d instead", *config.DefaultErrnoRet, maxErrno)
+	}
+	if config.DefaultAction == Allow {
+		report(SeverityInfo, "default-allow", "",
+			"default action allows every syscall that is not listed")
+	}
+
+	// Rules.
+	seen := make(map[string][]*Syscall)
+	for _, call := range config.Syscalls {
+		if call == nil {
+			report(SeverityError, "invalid-rule", "", "encountered nil syscall")
+			continue
+		}
+		if call.Name == "" {
+			report(SeverityError, "invalid-rule", "", "empty string is not a valid syscall")
+			continue
+		}
+		lintSyscallName(call.Name, arches, report)
+		lintArgs(call, report)
+		if call.ErrnoRet != nil && call.Action == Errno && *call.ErrnoRet > maxErrno {
+			report(SeverityWarning, "invalid-errno", call.Name,
+				"errno %d is out of range, the kernel returns %d instead", *call.ErrnoRet, maxErrno)
+		}
+
+		if call.Action == config.DefaultAction {
+			report(SeverityWarning, "redundant-rule", call.Name,
+				"action %s is the default action, libseccomp refuses to load such rules", call.Action)
+		}
+		for _, other := range seen[call.Name] {
+			if !reflect.DeepEqual(other.Args, call.Args) {
+				continue
+			}
+			if other.Action == call.Action && reflect.DeepEqual(other.ErrnoRet, call.ErrnoRet) {
+				report(SeverityWarning, "duplicate-rule", call.Name, "listed more than once")
+			} else {
+				report(SeverityError, "conflicting-rule", call.Name,
+					"listed with actions %s and %s for the same arguments", other.Action, call.Action)
+			}
+			break
+		}
+		seen[call.Name] = append(seen[call.Name], call)
+	}
+
+	// Supervised syscalls.
+	policed := make(map[string]bool)
+	for _, rule := range config.Notify {
+		if rule != nil {
+			for _, name := range rule.Names {
+				policed[name] = true
+			}
+		}
+	}
+	for name, rules := range seen {
+		for _, call := range rules {
+			if call.Action != UserNotif {
+				continue
+			}
+			if _, ok := notifyArgs[name]; !ok {
+				report(SeverityError, "invalid-notify", name, "the supervisor cannot make it")
+			} else if !policed[name] {
+				report(SeverityWarning, "invalid-notify", name,
+					"no notify rule allows it, the supervisor denies every call")
+			}
+			break
+		}
+	}
+
+	// Dangerous allowances.
+	for name, danger := range dangerousSyscalls {
+		rules := seen[name]
+		if len(rules) == 0 {
+			if allows(config.DefaultAction) {
+				report(danger.severity, "dangerous-syscall", name,
+					"allowed by the default action, it %s", danger.reason)
+			}
+			continue
+		}
+		for _, call := range rules {
+			if !allows(call.Action) {
+				continue
+			}
+			if len(call.Args) > 0 {
+				report(SeverityInfo, "dangerous-syscall", name,
+					"allowed for some arguments, review them as it %s", danger.reason)
+			} else {
+				report(danger.severity, "dangerous-syscall", name, "allowed, it %s", danger.reason)
+			}
+			break
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity > findings[j].Severity
+		}
+		if findings[i].Check != findings[j].Check {
+			return findings[i].Check < findings[j].Check
+		}
+		return findings[i].Syscall < findings[j].Syscall
+	})
+	return findings
+}
+
+// lintSyscallName reports syscalls which InitSeccomp ignores because
+// they do not exist on any architecture of the profile. Syscalls of
+// other architectures are common in portable profiles, only names that
+// are no syscall at all are mistakes.
+func lintSyscallName(name string, arches []Arch, report func(Severity, string, string, string, ...interface{})) {
+	checked := false
+	for _, arch := range arches {
+		table, ok := syscallTables[arch]
+		if !ok {
+			continue
+		}
+		if _, ok = table[name]; ok {
+			return
+		}
+		checked = true
+	}
+	if !checked {
+		return
+	}
+	for _, table := range syscallTables {
+		if _, ok := table[name]; ok {
+			report(SeverityInfo, "unknown-syscall", name,
+				"does not exist on any architecture of the profile, the rule is ignored")
+			return
+		}
+	}
+	report(SeverityError, "unknown-syscall", name, "is not a syscall, the rule is ignored")
+}
+
+// lintArgs reports argument conditions that fail to load or never match.
+func lintArgs(call *Syscall, report func(Severity, string, string, string, ...interface{})) {
+	indexes := make(map[uint]bool)
+	for _, arg := range call.Args {
+		if arg == nil {
+			report(SeverityError, "invalid-arg", call.Name, "cannot convert nil to syscall condition")
+			continue
+		}
+		if arg.Index > 5 {
+			report(SeverityError, "invalid-arg", call.Name, "argument index %d is out of range", arg.Index)
+		}
+		if indexes[arg.Index] {
+			report(SeverityError, "invalid-arg", call.Name,
+				"argument %d is compared more than once, libseccomp refuses to load such rules", arg.Index)
+		}
+		indexes[arg.Index] = true
+		if resolved, err := arg.resolve(nativeArch(runtime.GOARCH)); err == nil {
+			arg = resolved
+		}
+		if arg.Op == MaskEqualTo && arg.ValueTwo&^arg.Value != 0 {
+			report(SeverityWarning, "invalid-arg", call.Name,
+				"argument %d masked with %#x never equals %#x", arg.Index, arg.Value, arg.ValueTwo)
+		}
+	}
+}
+
+// allows reports if an action lets the syscall run.
+func allows(act Action) bool {
+	return act == Allow || act == Trace || act == Log
+}
@@ -0,0 +1,102 @@
+package seccomp
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Conflict is a rule profiles merged by Merge disagree on, Syscall is
+// empty for the default action. Actions lists the action of each
+// profile in order, empty for profiles without the rule.
+type Conflict struct {
+	Syscall string   `json:"syscall,omitempty"`
+	Args    string   `json:"args,omitempty"`
+	Actions []string `json:"actions"`
+}
+
+// Merge unions profiles once their bases are resolved: architectures,
+// rules told apart by syscall and argument conditions like Diff does,
+// and notify rules. Profiles with different default actions, or with
+// different actions for the same rule, conflict and the first profile
+// having the rule wins.
+func Merge(profiles ...*Seccomp) (*Seccomp, []Conflict, error) {
+	if len(profiles) == 0 {
+		return nil, nil, fmt.Errorf("no profiles to merge")
+	}
+	normals := make([]*normalProfile, len(profiles))
+	for i, profile := range profiles {
+		normal, err := normalizeRules(profile)
+		if err != nil {
+			return nil, nil, err
+		}
+		normals[i] = normal
+	}
+
+	merged := &Seccomp{
+		DefaultAction:   normals[0].config.DefaultAction,
+		DefaultErrnoRet: normals[0].config.DefaultErrnoRet,
+	}
+	var conflicts []Conflict
+	defaults := make([]string, len(normals))
+	for i, normal := range normals {
+		defaults[i] = normal.defaultAction
+		for _, rule := range normal.config.Notify {
+			if !hasNotifyRule(merged.Notify, rule) {
+				merged.Notify = append(merged.Notify, rule)
+			}
+		}
+	}
+	for _, action := range defaults {
+		if action != defaults[0] {
+			conflicts = append(conflicts, Conflict{Actions: defaults})
+			break
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, normal := range normals {
+		for _, arch := range normal.arches {
+			if !inArches(merged.Architectures, arch) {
+				merged.Architectures = append(merged.Architectures, arch)
+			}
+		}
+		for _, key := range normal.order {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			rule := normal.rules[key]
+			merged.Syscalls = append(merged.Syscalls, rule.call)
+
+			actions := make([]string, len(normals))
+			conflicting := false
+			for j, other := range normals[i:] {
+				if same, ok := other.rules[key]; ok {
+					actions[i+j] = same.action
+					conflicting = conflicting || same.action != rule.action
+				}
+			}
+			if conflicting {
+				conflicts = append(conflicts, Conflict{Syscall: rule.name, Args: rule.args, Actions: actions})
+			}
+		}
+	}
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		if conflicts[i].Syscall != conflicts[j].Syscall {
+			return conflicts[i].Syscall < conflicts[j].Syscall
+		}
+		return conflicts[i].Args < conflicts[j].Args
+	})
+	return merged, conflicts, nil
+}
+
+// hasNotifyRule reports if rules has one equal to rule.
+func hasNotifyRule(rules []*NotifyRule, rule *NotifyRule) bool {
+	for _, other := range rules {
+		if reflect.DeepEqual(other, rule) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+// +build ignore
+
+// mkflags generates the values of the symbolic names syscall arguments
+// may be given by in profiles from the zerrors_linux_*.go files of
+// golang.org/x/sys/unix, adding the flags defined since.
+//
+//	go run mkflags.go $GOPATH/pkg/mod/golang.org/x/sys@<version>/unix > zflags.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Architectures with a table, by the Go architecture of their zerrors
+// file. x32 shares the table of amd64.
+var tables = []struct {
+	arch, goarch string
+}{
+	{"x86", "386"},
+	{"amd64", "amd64"},
+	{"arm", "arm"},
+	{"arm64", "arm64"},
+	{"mips", "mips"},
+	{"mipsel", "mipsle"},
+	{"mips64", "mips64"},
+	{"mipsel64", "mips64le"},
+	{"ppc64le", "ppc64le"},
+	{"s390x", "s390x"},
+	{"riscv64", "riscv64"},
+}
+
+// Constants matching flagRE which are masks or shifts rather than
+// values of arguments.
+var excluded = map[string]bool{
+	"AF_MAX":         true,
+	"MAP_HUGE_MASK":  true,
+	"MAP_HUGE_SHIFT": true,
+	"MAP_TYPE":       true,
+	"O_ACCMODE":      true,
+	"SOCK_IOC_TYPE":  true,
+}
+
+// Flags added to all architectures since.
+var commonFlags = map[string]uint64{
+	"CLONE_NEWTIME":       0x80,
+	"CLONE_PIDFD":         0x1000,
+	"CLONE_CLEAR_SIGHAND": 0x100000000,
+	"CLONE_INTO_CGROUP":   0x200000000,
+}
+
+var flagRE = regexp.MustCompile(`^\s+((?:AF|CLONE|MAP|O|PROT|SOCK)_[A-Z0-9_]+)\s+=\s+(0x[0-9a-f]+|[0-9]+)$`)
+
+func readTable(dir, goarch string) (map[string]uint64, error) {
+	f, err := os.Open(filepath.Join(dir, "zerrors_linux_"+goarch+".go"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	table := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		m := flagRE.FindStringSubmatch(scanner.Text())
+		if m == nil || excluded[m[1]] {
+			continue
+		}
+		value, err := strconv.ParseUint(m[2], 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		table[m[1]] = value
+	}
+	for name, value := range commonFlags {
+		table[name] = value
+	}
+	return table, scanner.Err()
+}
+
+func writeTable(buf *bytes.Buffer, table map[string]uint64) {
+	names := make([]string, 0, len(table))
+	for name := range table {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(buf, "%q: %#x,\n", name, table[name])
+	}
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: go run mkflags.go X-SYS-UNIX-DIR")
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mkflags.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package seccomp\n\n")
+	fmt.Fprintf(&buf, "// flagTables are the values of the symbolic names of syscall arguments\n")
+	fmt.Fprintf(&buf, "// of each architecture.\n")
+	fmt.Fprintf(&buf, "var flagTables = map[Arch]map[string]uint64{\n")
+	for _, t := range tables {
+		table, err := readTable(os.Args[1], t.goarch)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(&buf, "%q: {\n", t.arch)
+		writeTable(&buf, table)
+		fmt.Fprintf(&buf, "},\n")
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Stdout.Write(src)
+}
@@ -0,0 +1,66 @@
+// +build ignore
+
+// mkprofiles embeds the default profiles of profiles/, named
+// NAME@VERSION.json, in the package.
+//
+//	go run mkprofiles.go profiles > zprofiles.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var nameRE = regexp.MustCompile(`^[a-z0-9-]+@v[0-9]+\.json$`)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: go run mkprofiles.go PROFILES-DIR")
+		os.Exit(1)
+	}
+	files, err := filepath.Glob(filepath.Join(os.Args[1], "*.json"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	sort.Strings(files)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mkprofiles.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package seccomp\n\n")
+	fmt.Fprintf(&buf, "// defaultProfiles are the default profiles by NAME@VERSION.\n")
+	fmt.Fprintf(&buf, "var defaultProfiles = map[string]string{\n")
+	for _, file := range files {
+		name := filepath.Base(file)
+		if !nameRE.MatchString(name) {
+			fmt.Fprintf(os.Stderr, "%s is not named NAME@VERSION.json\n", file)
+			os.Exit(1)
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if !json.Valid(data) || bytes.IndexByte(data, '`') >= 0 {
+			fmt.Fprintf(os.Stderr, "%s is not a valid profile\n", file)
+			os.Exit(1)
+		}
+		fmt.Fprintf(&buf, "%q: `%s`,\n", strings.TrimSuffix(name, ".json"), data)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Stdout.Write(src)
+}
@@ -0,0 +1,260 @@
+// +build ignore
+
+// mksyscalls generates the syscall tables of the native backend from
+// the zsysnum_linux_*.go files of golang.org/x/sys/unix, adding the
+// syscalls numbered alike on all architectures since Linux 5.0.
+//
+//	go run mksyscalls.go $GOPATH/pkg/mod/golang.org/x/sys@<version>/unix > zsyscalls.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Architectures with a table, by the Go architecture of their
+// zsysnum file, with the offset of the syscalls numbered alike. Those
+// multiplexing System V IPC through ipc(2) got direct syscalls in 5.1.
+var tables = []struct {
+	arch, goarch string
+	base         int
+	bits32, ipc  bool
+}{
+	{"x86", "386", 0, true, true},
+	{"amd64", "amd64", 0, false, false},
+	{"arm", "arm", 0, true, false},
+	{"arm64", "arm64", 0, false, false},
+	{"mips", "mips", 4000, true, true},
+	{"mipsel", "mipsle", 4000, true, true},
+	{"mips64", "mips64", 5000, false, false},
+	{"mipsel64", "mips64le", 5000, false, false},
+	{"ppc64le", "ppc64le", 0, false, true},
+	{"s390x", "s390x", 0, false, true},
+	{"riscv64", "riscv64", 0, false, false},
+}
+
+var ipcSyscalls = map[string]int{
+	"semget": 393,
+	"semctl": 394,
+	"shmget": 395,
+	"shmctl": 396,
+	"shmat":  397,
+	"shmdt":  398,
+	"msgget": 399,
+	"msgsnd": 400,
+	"msgrcv": 401,
+	"msgctl": 402,
+}
+
+// Syscalls added to 32-bit architectures in Linux 5.1 for 64-bit time.
+var time64Syscalls = map[string]int{
+	"clock_gettime64":              403,
+	"clock_settime64":              404,
+	"clock_adjtime64":              405,
+	"clock_getres_time64":          406,
+	"clock_nanosleep_time64":       407,
+	"timer_gettime64":              408,
+	"timer_settime64":              409,
+	"timerfd_gettime64":            410,
+	"timerfd_settime64":            411,
+	"utimensat_time64":             412,
+	"pselect6_time64":              413,
+	"ppoll_time64":                 414,
+	"io_pgetevents_time64":         416,
+	"recvmmsg_time64":              417,
+	"mq_timedsend_time64":          418,
+	"mq_timedreceive_time64":       419,
+	"semtimedop_time64":            420,
+	"rt_sigtimedwait_time64":       421,
+	"futex_time64":                 422,
+	"sched_rr_get_interval_time64": 423,
+}
+
+// Syscalls added to all architectures since.
+var commonSyscalls = map[string]int{
+	"pidfd_send_signal":       424,
+	"io_uring_setup":          425,
+	"io_uring_enter":          426,
+	"io_uring_register":       427,
+	"open_tree":               428,
+	"move_mount":              429,
+	"fsopen":                  430,
+	"fsconfig":                431,
+	"fsmount":                 432,
+	"fspick":                  433,
+	"pidfd_open":              434,
+	"clone3":                  435,
+	"close_range":             436,
+	"openat2":                 437,
+	"pidfd_getfd":             438,
+	"faccessat2":              439,
+	"process_madvise":         440,
+	"epoll_pwait2":            441,
+	"mount_setattr":           442,
+	"quotactl_fd":             443,
+	"landlock_create_ruleset": 444,
+	"landlock_add_rule":       445,
+	"landlock_restrict_self":  446,
+	"memfd_secret":            447,
+	"process_mrelease":        448,
+	"futex_waitv":             449,
+	"set_mempolicy_home_node": 450,
+	"cachestat":               451,
+	"fchmodat2":               452,
+	"map_shadow_stack":        453,
+	"futex_wake":              454,
+	"futex_wait":              455,
+	"futex_requeue":           456,
+	"statmount":               457,
+	"listmount":               458,
+	"lsm_get_self_attr":       459,
+	"lsm_set_self_attr":       460,
+	"lsm_list_modules":        461,
+	"mseal":                   462,
+}
+
+// x32 uses the amd64 numbers with bit 30 set, except for syscalls
+// passing structures which differ in size, renumbered from 512.
+const x32SyscallBit = 0x40000000
+
+var x32Syscalls = map[string]int{
+	"rt_sigaction":      512,
+	"rt_sigreturn":      513,
+	"ioctl":             514,
+	"readv":             515,
+	"writev":            516,
+	"recvfrom":          517,
+	"sendmsg":           518,
+	"recvmsg":           519,
+	"execve":            520,
+	"ptrace":            521,
+	"rt_sigpending":     522,
+	"rt_sigtimedwait":   523,
+	"rt_sigqueueinfo":   524,
+	"sigaltstack":       525,
+	"timer_create":      526,
+	"mq_notify":         527,
+	"kexec_load":        528,
+	"waitid":            529,
+	"set_robust_list":   530,
+	"get_robust_list":   531,
+	"vmsplice":          532,
+	"move_pages":        533,
+	"preadv":            534,
+	"pwritev":           535,
+	"rt_tgsigqueueinfo": 536,
+	"recvmmsg":          537,
+	"sendmmsg":          538,
+	"process_vm_readv":  539,
+	"process_vm_writev": 540,
+	"setsockopt":        541,
+	"getsockopt":        542,
+	"io_setup":          543,
+	"io_submit":         544,
+	"execveat":          545,
+	"preadv2":           546,
+	"pwritev2":          547,
+}
+
+var sysnumRE = regexp.MustCompile(`^\s+SYS_([A-Z0-9_]+)\s+=\s+([0-9]+)$`)
+
+func readTable(dir, goarch string) (map[string]int, error) {
+	f, err := os.Open(filepath.Join(dir, "zsysnum_linux_"+goarch+".go"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	table := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		m := sysnumRE.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		nr, err := strconv.Atoi(m[2])
+		if err != nil {
+			return nil, err
+		}
+		table[strings.ToLower(m[1])] = nr
+	}
+	return table, scanner.Err()
+}
+
+func writeTable(buf *bytes.Buffer, arch string, table map[string]int) {
+	names := make([]string, 0, len(table))
+	for name := range table {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if table[names[i]] != table[names[j]] {
+			return table[names[i]] < table[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	fmt.Fprintf(buf, "%q: {\n", arch)
+	for _, name := range names {
+		fmt.Fprintf(buf, "%q: %d,\n", name, table[name])
+	}
+	fmt.Fprintf(buf, "},\n")
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: go run mksyscalls.go X-SYS-UNIX-DIR")
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by mksyscalls.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package seccomp\n\n")
+	fmt.Fprintf(&buf, "// syscallTables are the syscall numbers of each architecture by name.\n")
+	fmt.Fprintf(&buf, "var syscallTables = map[Arch]map[string]int{\n")
+	for _, t := range tables {
+		table, err := readTable(os.Args[1], t.goarch)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if t.ipc {
+			for name, nr := range ipcSyscalls {
+				table[name] = t.base + nr
+			}
+		}
+		if t.bits32 {
+			for name, nr := range time64Syscalls {
+				table[name] = t.base + nr
+			}
+		}
+		for name, nr := range commonSyscalls {
+			table[name] = t.base + nr
+		}
+		writeTable(&buf, t.arch, table)
+
+		if t.arch == "amd64" {
+			x32 := make(map[string]int)
+			for name, nr := range table {
+				if x32nr, ok := x32Syscalls[name]; ok {
+					nr = x32nr
+				}
+				x32[name] = x32SyscallBit | nr
+			}
+			writeTable(&buf, "x32", x32)
+		}
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Stdout.Write(src)
+}
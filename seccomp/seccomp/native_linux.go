@@ -0,0 +1,125 @@
+// +build linux
+
+package seccomp
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// SeccompModeFilter refers to the syscall argument SECCOMP_MODE_FILTER.
+var SeccompModeFilter = uintptr(2)
+
+// Filters given syscalls in a container, preventing them from being used
+// Started in the container init process, and carried over to all child processes
+// Setns calls, however, require a separate invocation, as they are not children
+// of the init until they join the namespace
+// Rules of syscalls that do not exist are skipped and reported in the result.
+func InitSeccomp(config *Seccomp) (*InitResult, error) {
+	return InitSeccompWith(config, InitOptions{})
+}
+
+// InitSeccompWith is InitSeccomp loading the filter as opts say.
+func InitSeccompWith(config *Seccomp, opts InitOptions) (*InitResult, error) {
+	if config == nil {
+		return nil, fmt.Errorf("cannot initialize Seccomp - nil config passed")
+	}
+	config, err := Resolve(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot initialize Seccomp - %s", err)
+	}
+
+	if hasNotify(config) {
+		if config, err = initNotify(config, opts.Notify, opts.Tsync); err != nil {
+			return nil, fmt.Errorf("cannot initialize Seccomp - %s", err)
+		}
+	}
+
+	if opts.Backend == "" {
+		opts.Backend = DefaultBackend
+	}
+	switch opts.Backend {
+	case BackendLibseccomp:
+		return initLibseccomp(config, opts.Strict, opts.Tsync)
+	case BackendNative:
+		return initNative(config, opts.Strict, opts.Tsync)
+	default:
+		return nil, fmt.Errorf("cannot initialize Seccomp - unknown backend %s", opts.Backend)
+	}
+}
+
+// IsEnabled returns if the kernel has been configured to support seccomp.
+func IsEnabled() bool {
+	// Check if Seccomp is supported, via CONFIG_SECCOMP.
+	_, _, err := syscall.RawSyscall(syscall.SYS_PRCTL, syscall.PR_GET_SECCOMP, 0, 0)
+	if err != syscall.EINVAL {
+		// Make sure the kernel has CONFIG_SECCOMP_FILTER.
+		_, _, err = syscall.RawSyscall(syscall.SYS_PRCTL, syscall.PR_SET_SECCOMP, SeccompModeFilter, 0)
+		if err != syscall.EINVAL {
+			return true
+		}
+	}
+	return false
+}
+
+// sockFprog is laid out like the kernel's struct sock_fprog.
+type sockFprog struct {
+	Len    uint16
+	Filter *SockFilter
+}
+
+// initNative compiles config and loads it, like libseccomp the no new
+// privileges bit is left to the caller.
+func initNative(config *Seccomp, strict, tsync bool) (*InitResult, error) {
+	native := nativeArch(runtime.GOARCH)
+	arches := []Arch{native}
+	for _, arch := range config.Architectures {
+		if !inArches(arches, arch) {
+			arches = append(arches, arch)
+		}
+	}
+	result := newInitResult(config, arches, func(arch Arch, name string) bool {
+		_, ok := syscallTables[arch][name]
+		return ok
+	}, func(name string) bool {
+		for _, table := range syscallTables {
+			if _, ok := table[name]; ok {
+				return true
+			}
+		}
+		return false
+	})
+	if err := result.check(strict); err != nil {
+		return result, fmt.Errorf("error initializing seccomp - %s", err)
+	}
+
+	prog, err := compile(config, native)
+	if err != nil {
+		return result, fmt.Errorf("error compiling seccomp filter: %s", err)
+	}
+	return result, loadFilter(prog, tsync)
+}
+
+// loadFilter loads a BPF program on the calling thread, or on every
+// thread of the process with tsync.
+func loadFilter(prog []SockFilter, tsync bool) error {
+	if tsync {
+		tid, err := seccompFilter(seccompFilterFlagTsync, prog)
+		if err != nil {
+			return fmt.Errorf("error loading seccomp filter into kernel: %s", err)
+		}
+		if tid != 0 {
+			return &TsyncError{Tid: int(tid)}
+		}
+		return nil
+	}
+	fprog := sockFprog{Len: uint16(len(prog)), Filter: &prog[0]}
+	_, _, e1 := syscall.RawSyscall(syscall.SYS_PRCTL, syscall.PR_SET_SECCOMP, SeccompModeFilter, uintptr(unsafe.Pointer(&fprog)))
+	runtime.KeepAlive(prog)
+	if e1 != 0 {
+		return fmt.Errorf("error loading seccomp filter into kernel: %s", e1)
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package seccomp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// NotifyRule is the policy of the supervisor of UserNotif rules, it
+// allows the syscalls Names when the path they access is one of Paths
+// or below, or when the address they connect or bind to is one of
+// Addresses. Addresses are HOST:PORT, where both may be "*", or
+// unix:PATH. Denied syscalls fail with the ErrnoRet of the rule closest
+// to allowing them, EPERM when unset.
+type NotifyRule struct {
+	Names     []string `json:"names"`
+	Paths     []string `json:"paths,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
+	ErrnoRet  *uint    `json:"errnoRet,omitempty"`
+}
+
+// notifyArg locates the path or socket address a supervised syscall
+// accesses in its arguments, dirfd is -1 for paths relative to the
+// working directory and addrlen -1 for paths.
+type notifyArg struct {
+	dirfd, ptr, addrlen int
+}
+
+// Syscalls the supervisor can make on behalf of the processes, every
+// other syscall is allowed by the notification filter so that the
+// sandbox can hand the listener over to the supervisor.
+var notifyArgs = map[string]notifyArg{
+	"open":     {-1, 0, -1},
+	"creat":    {-1, 0, -1},
+	"openat":   {0, 1, -1},
+	"mkdir":    {-1, 0, -1},
+	"mkdirat":  {0, 1, -1},
+	"rmdir":    {-1, 0, -1},
+	"unlink":   {-1, 0, -1},
+	"unlinkat": {0, 1, -1},
+	"truncate": {-1, 0, -1},
+	"connect":  {-1, 1, 2},
+	"bind":     {-1, 1, 2},
+}
+
+// atFdcwd is AT_FDCWD, the dirfd of paths relative to the working
+// directory.
+const atFdcwd = -100
+
+// hasNotify reports if config has UserNotif rules, which need a
+// supervisor.
+func hasNotify(config *Seccomp) bool {
+	for _, call := range config.Syscalls {
+		if call != nil && call.Action == UserNotif {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsSupervisor reports if config or the bases it extends have
+// UserNotif rules, whose listener InitSeccompWith hands over to the
+// Notify function of its options.
+func NeedsSupervisor(config *Seccomp) bool {
+	if config == nil {
+		return false
+	}
+	resolved, err := Resolve(config)
+	if err != nil {
+		return false
+	}
+	return hasNotify(resolved)
+}
+
+// splitNotify splits config into the filter notifying the supervisor of
+// the syscalls of UserNotif rules, and the filter of the other rules
+// allowing those syscalls. The kernel runs both filters and notifies
+// the supervisor unless the other filter denies the syscall.
+func splitNotify(config *Seccomp) (notify, rest *Seccomp, err error) {
+	notify = &Seccomp{DefaultAction: Allow, Architectures: config.Architectures}
+	copied := *config
+	rest = &copied
+	rest.Syscalls = nil
+	for _, call := range config.Syscalls {
+		if call == nil || call.Action != UserNotif {
+			rest.Syscalls = append(rest.Syscalls, call)
+			continue
+		}
+		if _, ok := notifyArgs[call.Name]; !ok {
+			return nil, nil, fmt.Errorf("the supervisor cannot make syscall %s", call.Name)
+		}
+		notify.Syscalls = append(notify.Syscalls, call)
+		allowed := *call
+		allowed.Action = Allow
+		rest.Syscalls = append(rest.Syscalls, &allowed)
+	}
+	if config.DefaultAction == UserNotif {
+		return nil, nil, fmt.Errorf("default action cannot be %s", UserNotif)
+	}
+	return notify, rest, nil
+}
+
+// notifyPolicy is a NotifyRule ready to match syscalls.
+type notifyPolicy struct {
+	paths    []string
+	unix     []string
+	inet     []inetAddress
+	errnoRet uint
+}
+
+// inetAddress matches IP addresses and ports, nil ips and a zero port
+// match any.
+type inetAddress struct {
+	ips  []net.IP
+	port int
+}
+
+// newNotifyPolicies indexes rules by syscall, resolving host names.
+func newNotifyPolicies(rules []*NotifyRule) (map[string][]*notifyPolicy, error) {
+	policies := make(map[string][]*notifyPolicy)
+	for i, rule := range rules {
+		if rule == nil {
+			return nil, fmt.Errorf("notify[%d]: encountered nil rule", i)
+		}
+		policy := &notifyPolicy{errnoRet: errnoEPERM}
+		if rule.ErrnoRet != nil {
+			policy.errnoRet = *rule.ErrnoRet
+		}
+		for _, path := range rule.Paths {
+			if !filepath.IsAbs(path) {
+				return nil, fmt.Errorf("notify[%d]: path %s is not absolute", i, path)
+			}
+			policy.paths = append(policy.paths, filepath.Clean(path))
+		}
+		for _, address := range rule.Addresses {
+			if strings.HasPrefix(address, "unix:") {
+				policy.unix = append(policy.unix, strings.TrimPrefix(address, "unix:"))
+				continue
+			}
+			addr, err := parseInetAddress(address)
+			if err != nil {
+				return nil, fmt.Errorf("notify[%d]: %s", i, err)
+			}
+			policy.inet = append(policy.inet, addr)
+		}
+		for _, name := range rule.Names {
+			arg, ok := notifyArgs[name]
+			if !ok {
+				return nil, fmt.Errorf("notify[%d]: the supervisor cannot make syscall %s", i, name)
+			}
+			if arg.addrlen < 0 && len(rule.Addresses) > 0 {
+				return nil, fmt.Errorf("notify[%d]: syscall %s accesses paths, not addresses", i, name)
+			}
+			if arg.addrlen >= 0 && len(rule.Paths) > 0 {
+				return nil, fmt.Errorf("notify[%d]: syscall %s accesses addresses, not paths", i, name)
+			}
+			policies[name] = append(policies[name], policy)
+		}
+	}
+	return policies, nil
+}
+
+func parseInetAddress(address string) (inetAddress, error) {
+	var addr inetAddress
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return addr, err
+	}
+	if port != "*" {
+		if addr.port, err = strconv.Atoi(port); err != nil || addr.port <= 0 || addr.port > 65535 {
+			return addr, fmt.Errorf("invalid port in address %s", address)
+		}
+	}
+	if host != "*" {
+		if ip := net.ParseIP(host); ip != nil {
+			addr.ips = []net.IP{ip}
+		} else if addr.ips, err = net.LookupIP(host); err != nil {
+			return addr, err
+		}
+	}
+	return addr, nil
+}
+
+// allowsPath reports if path is one of the paths of the policy or below.
+func (p *notifyPolicy) allowsPath(path string) bool {
+	for _, allowed := range p.paths {
+		if path == allowed || allowed == "/" || strings.HasPrefix(path, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsAddress reports if the socket address sa is one of the
+// addresses of the policy.
+func (p *notifyPolicy) allowsAddress(sa sockaddr) bool {
+	if sa.unix != "" {
+		for _, path := range p.unix {
+			if sa.unix == path {
+				return true
+			}
+		}
+		return false
+	}
+	if sa.ip == nil {
+		return false
+	}
+	for _, addr := range p.inet {
+		if addr.port != 0 && addr.port != sa.port {
+			continue
+		}
+		if addr.ips == nil {
+			return true
+		}
+		for _, ip := range addr.ips {
+			if ip.Equal(sa.ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deniedErrno returns the errno of a syscall allowed by none of
+// policies, the one of the policy closest to allowing path or sa: with
+// the most leading path elements in common, or an address of the same
+// family and then the same IP or port.
+func deniedErrno(policies []*notifyPolicy, path string, sa sockaddr) uint {
+	errno, best := uint(errnoEPERM), -1
+	for _, p := range policies {
+		score := 0
+		if path != "" {
+			for _, allowed := range p.paths {
+				if n := commonPathElems(path, allowed); n > score {
+					score = n
+				}
+			}
+		} else if sa.unix != "" && len(p.unix) > 0 {
+			score = 1
+		} else if sa.ip != nil {
+			for _, addr := range p.inet {
+				n := 1
+				for _, ip := range addr.ips {
+					if ip.Equal(sa.ip) {
+						n += 2
+						break
+					}
+				}
+				if addr.port == sa.port {
+					n++
+				}
+				if n > score {
+					score = n
+				}
+			}
+		}
+		if score > best {
+			errno, best = p.errnoRet, score
+		}
+	}
+	return errno
+}
+
+// commonPathElems counts the leading elements two clean absolute paths
+// have in common.
+func commonPathElems(a, b string) int {
+	as, bs := strings.Split(a, "/")[1:], strings.Split(b, "/")[1:]
+	n := 0
+	for n < len(as) && n < len(bs) && as[n] == bs[n] && as[n] != "" {
+		n++
+	}
+	return n
+}
+
+// sockaddr is a decoded struct sockaddr of the unix, inet and inet6
+// families.
+type sockaddr struct {
+	unix string
+	ip   net.IP
+	port int
+}
+
+func (sa sockaddr) String() string {
+	if sa.unix != "" {
+		return "unix:" + sa.unix
+	}
+	if sa.ip == nil {
+		return "unsupported address"
+	}
+	return net.JoinHostPort(sa.ip.String(), strconv.Itoa(sa.port))
+}
+
+// Address families of struct sockaddr, the same on every architecture.
+const (
+	afUnix  = 1
+	afInet  = 2
+	afInet6 = 10
+)
+
+// parseSockaddr decodes a struct sockaddr, the family is in host byte
+// order and ports in network byte order.
+func parseSockaddr(data []byte, order binary.ByteOrder) sockaddr {
+	var sa sockaddr
+	if len(data) < 2 {
+		return sa
+	}
+	switch order.Uint16(data) {
+	case afUnix:
+		path := data[2:]
+		if i := strings.IndexByte(string(path), 0); i >= 0 {
+			path = path[:i]
+		}
+		if len(path) == 0 {
+			// Abstract sockets start with a NUL byte.
+			if len(data) > 3 {
+				sa.unix = "@" + strings.TrimRight(string(data[3:]), "\x00")
+			}
+			return sa
+		}
+		sa.unix = string(path)
+	case afInet:
+		if len(data) >= 8 {
+			sa.port = int(binary.BigEndian.Uint16(data[2:]))
+			sa.ip = net.IP(append([]byte(nil), data[4:8]...))
+		}
+	case afInet6:
+		if len(data) >= 24 {
+			sa.port = int(binary.BigEndian.Uint16(data[2:]))
+			sa.ip = net.IP(append([]byte(nil), data[8:24]...))
+		}
+	}
+	return sa
+}
@@ -0,0 +1,645 @@
+// +build linux
+
+package seccomp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// Operations and flags of the seccomp syscall.
+const (
+	seccompSetModeFilter         = 1
+	seccompFilterFlagTsync       = 1
+	seccompFilterFlagNewListener = 1 << 3
+	seccompFilterFlagTsyncESRCH  = 1 << 4
+	seccompAddfdFlagSend         = 1 << 1
+)
+
+// seccompNotif is laid out like the kernel's struct seccomp_notif.
+type seccompNotif struct {
+	ID    uint64
+	Pid   uint32
+	Flags uint32
+	Data  SeccompData
+}
+
+// seccompNotifAddfd is laid out like the kernel's struct
+// seccomp_notif_addfd.
+type seccompNotifAddfd struct {
+	ID         uint64
+	Flags      uint32
+	Srcfd      uint32
+	Newfd      uint32
+	NewfdFlags uint32
+}
+
+// seccompNotifResp is laid out like the kernel's struct
+// seccomp_notif_resp.
+type seccompNotifResp struct {
+	ID    uint64
+	Val   int64
+	Error int32
+	Flags uint32
+}
+
+// Ioctls of the listener, Linux 5.9 fixed the direction of ID_VALID to
+// write but still accepts read, which older kernels expect.
+var (
+	ioctlNotifRecv    = iowr('!', 0, unsafe.Sizeof(seccompNotif{}))
+	ioctlNotifSend    = iowr('!', 1, unsafe.Sizeof(seccompNotifResp{}))
+	ioctlNotifIDValid = ior('!', 2, 8)
+	ioctlNotifAddfd   = iow('!', 3, unsafe.Sizeof(seccompNotifAddfd{}))
+)
+
+// Encoding of ioctl numbers, mips and powerpc differ from the others.
+func ioc(dir, typ, nr, size uintptr) uintptr {
+	sizeBits, write, read := uintptr(14), uintptr(1), uintptr(2)
+	switch runtime.GOARCH {
+	case "mips", "mipsle", "mips64", "mips64le", "ppc64", "ppc64le":
+		sizeBits, write, read = 13, 4, 2
+	}
+	dirs := map[uintptr]uintptr{1: write, 2: read, 3: write | read}
+	return dirs[dir]<<(16+sizeBits) | size<<16 | typ<<8 | nr
+}
+
+func iowr(typ, nr, size uintptr) uintptr { return ioc(3, typ, nr, size) }
+func ior(typ, nr, size uintptr) uintptr  { return ioc(2, typ, nr, size) }
+func iow(typ, nr, size uintptr) uintptr  { return ioc(1, typ, nr, size) }
+
+// seccompFilter loads prog on the calling thread with the seccomp
+// syscall, returning what it returns.
+func seccompFilter(flags uintptr, prog []SockFilter) (uintptr, error) {
+	nr, ok := syscallTables[nativeArch(runtime.GOARCH)]["seccomp"]
+	if !ok {
+		return 0, fmt.Errorf("seccomp syscall is not known on %s", runtime.GOARCH)
+	}
+	fprog := sockFprog{Len: uint16(len(prog)), Filter: &prog[0]}
+	r1, _, e1 := syscall.RawSyscall(uintptr(nr), seccompSetModeFilter, flags, uintptr(unsafe.Pointer(&fprog)))
+	runtime.KeepAlive(prog)
+	if e1 != 0 {
+		return 0, e1
+	}
+	return r1, nil
+}
+
+// initNotify loads the filter notifying the supervisor of the syscalls
+// of UserNotif rules, hands its listener to notify and returns the
+// rest of config. A thread failing to sync would be returned in place
+// of the listener, TSYNC_ESRCH makes the kernel fail with ESRCH instead.
+func initNotify(config *Seccomp, notify func(listener *os.File) error, tsync bool) (*Seccomp, error) {
+	if notify == nil {
+		return nil, fmt.Errorf("profile has %s rules but no supervisor", UserNotif)
+	}
+	notifyConfig, rest, err := splitNotify(config)
+	if err != nil {
+		return nil, err
+	}
+	prog, err := Compile(notifyConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling seccomp notification filter: %s", err)
+	}
+	flags := uintptr(seccompFilterFlagNewListener)
+	if tsync {
+		flags |= seccompFilterFlagTsync | seccompFilterFlagTsyncESRCH
+	}
+	fd, err := seccompFilter(flags, prog)
+	if err == syscall.ESRCH && tsync {
+		return nil, &TsyncError{}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading seccomp notification filter into kernel: %s", err)
+	}
+	listener := os.NewFile(fd, "seccomp-listener")
+	defer listener.Close()
+	if err = notify(listener); err != nil {
+		return nil, err
+	}
+	return rest, nil
+}
+
+// Supervisor makes the syscalls of UserNotif rules of processes
+// filtered with a profile on their behalf, when the Notify policy of the
+// profile allows them. It reads the path or address once, checks it and
+// uses what it checked: paths are opened without following symbolic
+// links once resolved and files are installed in the process with
+// SECCOMP_IOCTL_NOTIF_ADDFD, sockets are connected or bound through
+// pidfd_getfd. The kernel runs none of the notified syscalls itself, so
+// a thread rewriting the arguments cannot get around the policy.
+type Supervisor struct {
+	listener *os.File
+	policies map[string][]*notifyPolicy
+	// Denied is called for every denied syscall when set, it may be
+	// called concurrently.
+	Denied func(pid int, syscall, target string)
+}
+
+// NewSupervisor supervises the processes of the listener received by
+// the Notify function of InitOptions, config is their profile.
+func NewSupervisor(listener *os.File, config *Seccomp) (*Supervisor, error) {
+	config, err := Resolve(config)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := newNotifyPolicies(config.Notify)
+	if err != nil {
+		return nil, err
+	}
+	return &Supervisor{listener: listener, policies: policies}, nil
+}
+
+// Serve answers notifications until every supervised process exited.
+// Each is answered by its own goroutine, syscalls like connect may
+// block for long.
+func (s *Supervisor) Serve() error {
+	// Wakes the poll when answering fails.
+	wakeR, wakeW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer wakeR.Close()
+	defer wakeW.Close()
+	errCh := make(chan error, 1)
+	var wg sync.WaitGroup
+
+	fd := s.listener.Fd()
+	for {
+		// Notifications block until answered, the listener hangs up
+		// once no process uses the filter anymore.
+		pfds := [2]struct {
+			fd      int32
+			events  int16
+			revents int16
+		}{{int32(fd), pollIn, 0}, {int32(wakeR.Fd()), pollIn, 0}}
+		_, _, e1 := syscall.Syscall6(syscall.SYS_PPOLL, uintptr(unsafe.Pointer(&pfds[0])), 2, 0, 0, 0, 0)
+		if e1 == syscall.EINTR {
+			continue
+		}
+		if e1 != 0 {
+			return fmt.Errorf("error polling seccomp listener: %s", e1)
+		}
+		select {
+		case err = <-errCh:
+			return err
+		default:
+		}
+		if pfds[0].revents&pollIn == 0 {
+			wg.Wait()
+			return nil
+		}
+
+		notif := new(seccompNotif)
+		if _, _, e1 = syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlNotifRecv, uintptr(unsafe.Pointer(notif))); e1 != 0 {
+			// The process went away before its notification was read.
+			if e1 == syscall.ENOENT || e1 == syscall.EINTR {
+				continue
+			}
+			return fmt.Errorf("error receiving seccomp notification: %s", e1)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.answer(fd, notif); err != nil {
+				select {
+				case errCh <- err:
+					wakeW.Write([]byte{0})
+				default:
+				}
+			}
+		}()
+	}
+}
+
+// Events of ppoll.
+const pollIn = 0x1
+
+// answer makes the syscall of a notification when it is allowed and
+// returns its result to the process.
+func (s *Supervisor) answer(fd uintptr, notif *seccompNotif) error {
+	name, target, errno, call := s.decide(notif)
+	// Whatever was read may belong to another process when the
+	// notification is no longer valid.
+	id := notif.ID
+	if _, _, e1 := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlNotifIDValid, uintptr(unsafe.Pointer(&id))); e1 != 0 {
+		return nil
+	}
+
+	resp := seccompNotifResp{ID: notif.ID}
+	if call == nil {
+		resp.Error = -int32(errno)
+		if s.Denied != nil {
+			s.Denied(int(notif.Pid), name, target)
+		}
+	} else if result := call(); result.file < 0 {
+		resp.Val, resp.Error = result.val, -int32(result.errno)
+	} else {
+		defer syscall.Close(result.file)
+		addfd := seccompNotifAddfd{ID: notif.ID, Flags: seccompAddfdFlagSend, Srcfd: uint32(result.file)}
+		if result.cloexec {
+			addfd.NewfdFlags = uint32(flagTable(nativeArch(runtime.GOARCH))["O_CLOEXEC"])
+		}
+		r1, _, e1 := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlNotifAddfd, uintptr(unsafe.Pointer(&addfd)))
+		if e1 == 0 {
+			// Installing the file answered the notification.
+			return nil
+		}
+		if e1 == syscall.EINVAL {
+			// Linux before 5.14 installs the file without answering.
+			addfd.Flags = 0
+			r1, _, e1 = syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlNotifAddfd, uintptr(unsafe.Pointer(&addfd)))
+		}
+		if e1 == syscall.ENOENT {
+			return nil
+		}
+		resp.Val, resp.Error = int64(r1), -int32(e1)
+	}
+	if _, _, e1 := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlNotifSend, uintptr(unsafe.Pointer(&resp))); e1 != 0 && e1 != syscall.ENOENT {
+		return fmt.Errorf("error answering seccomp notification: %s", e1)
+	}
+	return nil
+}
+
+// brokered is the result of a syscall the supervisor made for a
+// process, file is installed in the process as the return value unless
+// it is negative.
+type brokered struct {
+	val     int64
+	errno   syscall.Errno
+	file    int
+	cloexec bool
+}
+
+// failed answers a notification with errno without making its syscall.
+func failed(errno syscall.Errno) func() brokered {
+	return func() brokered { return brokered{errno: errno, file: -1} }
+}
+
+// decide applies the policy to a notification, returning the syscall to
+// make for the process, or nil and the errno when it is denied.
+func (s *Supervisor) decide(notif *seccompNotif) (name, target string, errno uint, call func() brokered) {
+	errno = errnoEPERM
+	arch, name, ok := ResolveSyscall(notif.Data.Arch, uint64(uint32(notif.Data.Nr)))
+	if !ok {
+		return fmt.Sprintf("syscall %d", notif.Data.Nr), "", errno, nil
+	}
+	arg, ok := notifyArgs[name]
+	policies := s.policies[name]
+	if !ok || len(policies) == 0 {
+		return name, "", errno, nil
+	}
+	pid := int(notif.Pid)
+	args := notif.Data.Args
+
+	if arg.addrlen >= 0 {
+		// Larger than struct sockaddr_storage.
+		if args[arg.addrlen] > 128 {
+			return name, "", 0, failed(syscall.EINVAL)
+		}
+		data, err := readMemory(pid, args[arg.ptr], int(args[arg.addrlen]))
+		if err != nil {
+			return name, "", 0, failed(syscall.EFAULT)
+		}
+		order := binary.ByteOrder(binary.LittleEndian)
+		if archInfos[arch].bigEndian {
+			order = binary.BigEndian
+		}
+		sa := parseSockaddr(data, order)
+		// Relative socket paths would be resolved in the working
+		// directory of the supervisor.
+		if sa.unix == "" || strings.HasPrefix(sa.unix, "/") || strings.HasPrefix(sa.unix, "@") {
+			for _, policy := range policies {
+				if policy.allowsAddress(sa) {
+					return name, sa.String(), 0, func() brokered {
+						return brokerSocket(name, pid, int32(args[0]), data)
+					}
+				}
+			}
+		}
+		return name, sa.String(), deniedErrno(policies, "", sa), nil
+	}
+
+	native := flagTable(nativeArch(runtime.GOARCH))
+	var flags, mode, length uint64
+	switch name {
+	case "open", "openat":
+		i := 1
+		if name == "openat" {
+			i = 2
+		}
+		if flags, ok = nativeOpenFlags(args[i], arch); !ok {
+			return name, "", 0, failed(syscall.EINVAL)
+		}
+		mode = args[i+1]
+	case "creat":
+		flags, mode = native["O_CREAT"]|native["O_WRONLY"]|native["O_TRUNC"], args[1]
+	case "mkdir":
+		mode = args[1]
+	case "mkdirat":
+		mode = args[2]
+	case "unlinkat":
+		flags = args[2]
+	case "truncate":
+		length = args[1]
+		if archInfos[arch].bits32 && arch != "x32" {
+			length = uint64(int32(length))
+		}
+	}
+
+	path, err := readPath(pid, args[arg.ptr])
+	if err != nil {
+		return name, "", 0, failed(errnoOf(err, syscall.EFAULT))
+	}
+	if path == "" {
+		return name, "", 0, failed(syscall.ENOENT)
+	}
+	if !filepath.IsAbs(path) {
+		dir := "/proc/" + strconv.Itoa(pid) + "/cwd"
+		if arg.dirfd >= 0 {
+			if dirfd := int32(args[arg.dirfd]); dirfd != atFdcwd {
+				dir = "/proc/" + strconv.Itoa(pid) + "/fd/" + strconv.Itoa(int(dirfd))
+			}
+		}
+		if dir, err = os.Readlink(dir); err != nil {
+			return name, path, 0, failed(syscall.EBADF)
+		}
+		path = filepath.Join(dir, path)
+	}
+	path = procSelf(filepath.Clean(path), pid)
+
+	// The last element is followed like the kernel does, only opening
+	// and truncating files follow it.
+	follow := name == "truncate"
+	if name == "open" || name == "openat" {
+		excl := native["O_CREAT"] | native["O_EXCL"]
+		follow = flags&native["O_NOFOLLOW"] == 0 && flags&excl != excl
+	}
+	resolved, err := resolvePath(path, follow)
+	if err != nil {
+		// Only reveal why a path is missing when it may be accessed.
+		for _, policy := range policies {
+			if policy.allowsPath(path) {
+				return name, path, 0, failed(errnoOf(err, syscall.ELOOP))
+			}
+		}
+		return name, path, deniedErrno(policies, path, sockaddr{}), nil
+	}
+	allowed := false
+	for _, policy := range policies {
+		allowed = allowed || policy.allowsPath(resolved)
+	}
+	if !allowed {
+		return name, resolved, deniedErrno(policies, resolved, sockaddr{}), nil
+	}
+	mode &= 07777 &^ processUmask(pid)
+
+	return name, resolved, 0, func() brokered {
+		switch name {
+		case "open", "openat", "creat":
+			if flags&native["O_CREAT"] == 0 && flags&native["O_TMPFILE"] != native["O_TMPFILE"] {
+				mode = 0
+			}
+			fd, errno := openBeneath(resolved, flags|native["O_CLOEXEC"], mode)
+			if errno != 0 {
+				return brokered{errno: errno, file: -1}
+			}
+			return brokered{file: fd, cloexec: flags&native["O_CLOEXEC"] != 0}
+		case "truncate":
+			fd, errno := openBeneath(resolved, native["O_WRONLY"]|native["O_NONBLOCK"]|native["O_CLOEXEC"], 0)
+			if errno != 0 {
+				return brokered{errno: errno, file: -1}
+			}
+			defer syscall.Close(fd)
+			_, _, errno = syscall.Syscall(nativeNr("ftruncate"), uintptr(fd), uintptr(length), 0)
+			return brokered{errno: errno, file: -1}
+		}
+
+		dir, file := filepath.Split(resolved)
+		dirfd, errno := openBeneath(dir, native["O_PATH"]|native["O_DIRECTORY"]|native["O_CLOEXEC"], 0)
+		if errno != 0 {
+			return brokered{errno: errno, file: -1}
+		}
+		defer syscall.Close(dirfd)
+		p, err := syscall.BytePtrFromString(file)
+		if err != nil {
+			return brokered{errno: syscall.EINVAL, file: -1}
+		}
+		switch name {
+		case "mkdir", "mkdirat":
+			_, _, errno = syscall.Syscall(nativeNr("mkdirat"), uintptr(dirfd), uintptr(unsafe.Pointer(p)), uintptr(mode))
+		case "rmdir":
+			_, _, errno = syscall.Syscall(nativeNr("unlinkat"), uintptr(dirfd), uintptr(unsafe.Pointer(p)), atRemovedir)
+		default:
+			if flags&^atRemovedir != 0 {
+				return brokered{errno: syscall.EINVAL, file: -1}
+			}
+			_, _, errno = syscall.Syscall(nativeNr("unlinkat"), uintptr(dirfd), uintptr(unsafe.Pointer(p)), uintptr(flags))
+		}
+		return brokered{errno: errno, file: -1}
+	}
+}
+
+// AT_REMOVEDIR of unlinkat, the same on every architecture.
+const atRemovedir = 0x200
+
+// nativeOpenFlags translates open flags of a process of arch to those of
+// the supervisor, false when some are unknown.
+func nativeOpenFlags(flags uint64, arch Arch) (uint64, bool) {
+	from, to := flagTable(arch), flagTable(nativeArch(runtime.GOARCH))
+	// The access modes are the same everywhere.
+	native, known := flags&3, uint64(3)
+	for name, value := range from {
+		if !strings.HasPrefix(name, "O_") || value == 0 || value == 3 {
+			continue
+		}
+		if flags&value == value {
+			native |= to[name]
+			known |= value
+		}
+	}
+	return native, flags&^known == 0
+}
+
+// resolvePath resolves the symbolic links of an absolute path, the last
+// element only when follow is set or it does not exist yet.
+func resolvePath(path string, follow bool) (string, error) {
+	if follow {
+		resolved, err := filepath.EvalSymlinks(path)
+		if err == nil || !os.IsNotExist(err) {
+			return resolved, err
+		}
+	}
+	dir, file := filepath.Split(path)
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(resolved, file), nil
+}
+
+// procSelf points /proc/self and /proc/thread-self of path to the
+// process instead of the supervisor.
+func procSelf(path string, pid int) string {
+	var self string
+	switch {
+	case path == "/proc/self" || strings.HasPrefix(path, "/proc/self/"):
+		self = "/proc/self"
+	case path == "/proc/thread-self" || strings.HasPrefix(path, "/proc/thread-self/"):
+		self = "/proc/thread-self"
+	default:
+		return path
+	}
+	tgid, err := processStatus(pid, "Tgid")
+	if err != nil {
+		return path
+	}
+	proc := "/proc/" + tgid
+	if self == "/proc/thread-self" {
+		proc += "/task/" + strconv.Itoa(pid)
+	}
+	return proc + strings.TrimPrefix(path, self)
+}
+
+// processStatus returns a field of /proc/PID/status.
+func processStatus(pid int, field string) (string, error) {
+	data, err := ioutil.ReadFile("/proc/" + strconv.Itoa(pid) + "/status")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, field+":") {
+			return strings.TrimSpace(strings.TrimPrefix(line, field+":")), nil
+		}
+	}
+	return "", fmt.Errorf("no %s in status of process %d", field, pid)
+}
+
+// processUmask returns the umask of the process, files the supervisor
+// creates get the umask of both.
+func processUmask(pid int) uint64 {
+	umask, err := processStatus(pid, "Umask")
+	if err != nil {
+		return 0
+	}
+	mask, err := strconv.ParseUint(umask, 8, 32)
+	if err != nil {
+		return 0
+	}
+	return mask
+}
+
+// Resolve flags of openat2.
+const resolveNoSymlinks = 0x04
+
+// openBeneath opens a resolved path with openat2, failing rather than
+// following a symbolic link swapped in since it was resolved.
+func openBeneath(path string, flags, mode uint64) (int, syscall.Errno) {
+	p, err := syscall.BytePtrFromString(path)
+	if err != nil {
+		return -1, syscall.EINVAL
+	}
+	how := &struct {
+		flags   uint64
+		mode    uint64
+		resolve uint64
+	}{flags, mode, resolveNoSymlinks}
+	dirfd := atFdcwd
+	fd, _, errno := syscall.Syscall6(nativeNr("openat2"), uintptr(dirfd), uintptr(unsafe.Pointer(p)),
+		uintptr(unsafe.Pointer(how)), unsafe.Sizeof(*how), 0, 0)
+	if errno != 0 {
+		return -1, errno
+	}
+	return int(fd), 0
+}
+
+// brokerSocket connects or binds the socket fd of the process to the
+// socket address data.
+func brokerSocket(name string, pid int, fd int32, data []byte) brokered {
+	tgid, err := processStatus(pid, "Tgid")
+	if err != nil {
+		return brokered{errno: syscall.ESRCH, file: -1}
+	}
+	leader, err := strconv.Atoi(tgid)
+	if err != nil {
+		return brokered{errno: syscall.ESRCH, file: -1}
+	}
+	pidfd, _, errno := syscall.Syscall(nativeNr("pidfd_open"), uintptr(leader), 0, 0)
+	if errno != 0 {
+		return brokered{errno: errno, file: -1}
+	}
+	defer syscall.Close(int(pidfd))
+	sock, _, errno := syscall.Syscall(nativeNr("pidfd_getfd"), pidfd, uintptr(fd), 0)
+	if errno != 0 {
+		return brokered{errno: errno, file: -1}
+	}
+	defer syscall.Close(int(sock))
+	var ptr unsafe.Pointer
+	if len(data) > 0 {
+		ptr = unsafe.Pointer(&data[0])
+	}
+	_, _, errno = syscall.Syscall(nativeNr(name), sock, uintptr(ptr), uintptr(len(data)))
+	return brokered{errno: errno, file: -1}
+}
+
+// nativeNr returns the number of syscall name on the architecture of
+// the supervisor, or one failing with ENOSYS when it has none.
+func nativeNr(name string) uintptr {
+	if nr, ok := syscallTables[nativeArch(runtime.GOARCH)][name]; ok {
+		return uintptr(nr)
+	}
+	return ^uintptr(0)
+}
+
+// errnoOf returns the errno err wraps, or def.
+func errnoOf(err error, def syscall.Errno) syscall.Errno {
+	switch e := err.(type) {
+	case syscall.Errno:
+		return e
+	case *os.PathError:
+		return errnoOf(e.Err, def)
+	}
+	return def
+}
+
+// readPath reads a NUL terminated path of the process.
+func readPath(pid int, ptr uint64) (string, error) {
+	const pathMax = 4096
+	var path []byte
+	for len(path) < pathMax {
+		// Stay within the page, the next one may not be mapped.
+		n := 4096 - int((ptr+uint64(len(path)))%4096)
+		data, err := readMemory(pid, ptr+uint64(len(path)), n)
+		if err != nil {
+			return "", err
+		}
+		for i, c := range data {
+			if c == 0 {
+				return string(append(path, data[:i]...)), nil
+			}
+		}
+		path = append(path, data...)
+	}
+	return "", syscall.ENAMETOOLONG
+}
+
+// readMemory reads n bytes at ptr of the process.
+func readMemory(pid int, ptr uint64, n int) ([]byte, error) {
+	mem, err := os.Open("/proc/" + strconv.Itoa(pid) + "/mem")
+	if err != nil {
+		return nil, err
+	}
+	defer mem.Close()
+	data := make([]byte, n)
+	if _, err = mem.ReadAt(data, int64(ptr)); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,45 @@
+package seccomp
+
+import (
+	"net"
+	"testing"
+)
+
+func TestDeniedErrnoOfClosestRule(t *testing.T) {
+	refused, denied := uint(111), uint(13)
+	policies, err := newNotifyPolicies([]*NotifyRule{
+		{Names: []string{"openat"}, Paths: []string{"/usr/lib"}},
+		{Names: []string{"openat"}, Paths: []string{"/srv/data/in"}, ErrnoRet: &denied},
+		{Names: []string{"connect"}, Addresses: []string{"127.0.0.1:9000"}},
+		{Names: []string{"connect"}, Addresses: []string{"10.0.0.1:*"}, ErrnoRet: &refused},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := []struct {
+		path  string
+		errno uint
+	}{
+		{"/srv/data/out", denied},
+		{"/usr/bin/sh", errnoEPERM},
+		{"/etc/passwd", errnoEPERM},
+	}
+	for _, test := range paths {
+		if errno := deniedErrno(policies["openat"], test.path, sockaddr{}); errno != test.errno {
+			t.Errorf("openat %s denied with %d, expected %d", test.path, errno, test.errno)
+		}
+	}
+	addresses := []struct {
+		sa    sockaddr
+		errno uint
+	}{
+		{sockaddr{ip: net.ParseIP("10.0.0.1"), port: 22}, refused},
+		{sockaddr{ip: net.ParseIP("10.0.0.2"), port: 9000}, errnoEPERM},
+		{sockaddr{unix: "/run/docker.sock"}, errnoEPERM},
+	}
+	for _, test := range addresses {
+		if errno := deniedErrno(policies["connect"], "", test.sa); errno != test.errno {
+			t.Errorf("connect %s denied with %d, expected %d", test.sa, errno, test.errno)
+		}
+	}
+}
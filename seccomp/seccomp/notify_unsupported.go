@@ -0,0 +1,23 @@
+// +build !linux
+
+package seccomp
+
+import (
+	"os"
+)
+
+// Supervisor is not supported on this platform.
+type Supervisor struct {
+	// Denied is called for every denied syscall when set.
+	Denied func(pid int, syscall, target string)
+}
+
+// NewSupervisor fails, seccomp is not supported.
+func NewSupervisor(listener *os.File, config *Seccomp) (*Supervisor, error) {
+	return nil, ErrSeccompNotEnabled
+}
+
+// Serve fails, seccomp is not supported.
+func (s *Supervisor) Serve() error {
+	return ErrSeccompNotEnabled
+}
@@ -0,0 +1,66 @@
+package seccomp
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default profiles of lambdas are shipped in the package by name and
+// version like "go-lambda@v1", generated by mkprofiles.go from the
+// profiles directory. Released versions never change, changes to a
+// profile are released as its next version.
+
+// splitProfileRef splits NAME@VERSION, the version is 0 when missing.
+func splitProfileRef(ref string) (string, int, error) {
+	i := strings.LastIndexByte(ref, '@')
+	if i < 0 {
+		return ref, 0, nil
+	}
+	version, err := strconv.Atoi(strings.TrimPrefix(ref[i+1:], "v"))
+	if err != nil || !strings.HasPrefix(ref[i+1:], "v") || version < 1 {
+		return "", 0, fmt.Errorf("%s is not a valid profile version, must be like v1", ref[i+1:])
+	}
+	return ref[:i], version, nil
+}
+
+// DefaultProfiles lists the default profiles as NAME@VERSION, sorted by
+// name and version.
+func DefaultProfiles() []string {
+	refs := make([]string, 0, len(defaultProfiles))
+	for ref := range defaultProfiles {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		ni, vi, _ := splitProfileRef(refs[i])
+		nj, vj, _ := splitProfileRef(refs[j])
+		if ni != nj {
+			return ni < nj
+		}
+		return vi < vj
+	})
+	return refs
+}
+
+// DefaultProfile returns the JSON of the default profile ref, which is
+// NAME@VERSION or NAME for its latest version, and the NAME@VERSION
+// it resolves to.
+func DefaultProfile(ref string) ([]byte, string, error) {
+	name, version, err := splitProfileRef(ref)
+	if err != nil {
+		return nil, "", err
+	}
+	if version == 0 {
+		for _, r := range DefaultProfiles() {
+			if n, _, _ := splitProfileRef(r); n == name {
+				ref = r
+			}
+		}
+	}
+	data, ok := defaultProfiles[ref]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown default profile %s", ref)
+	}
+	return []byte(data), ref, nil
+}
@@ -0,0 +1,100 @@
+package seccomp
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// InitOptions configure how InitSeccompWith loads a filter.
+type InitOptions struct {
+	// Backend loading the filter, DefaultBackend when empty.
+	Backend Backend
+	// Strict fails to load profiles with unknown syscalls instead of
+	// skipping their rules.
+	Strict bool
+	// Tsync loads the filter on every thread of the process instead of
+	// the calling thread only, which Go programs need since goroutines
+	// run on any thread. Loading fails with a *TsyncError when a thread
+	// cannot be synchronized.
+	Tsync bool
+	// Notify hands the listener of profiles with UserNotif rules over
+	// to their Supervisor, it is called once the filter notifying the
+	// supervisor is loaded and before the rest of the profile is. The
+	// listener is closed when it returns.
+	Notify func(listener *os.File) error
+}
+
+// ExecOptions configure how Exec loads a filter before exec.
+type ExecOptions struct {
+	InitOptions
+	// Loaded is called with the result of loading the filter right
+	// before exec, when set.
+	Loaded func(result *InitResult)
+}
+
+// TsyncError reports a thread of the process the filter could not be
+// loaded on, because it has filters the calling thread does not have.
+// The kernel reports the first such thread only, its Tid is 0 when the
+// filter notifies a supervisor and the kernel does not tell.
+type TsyncError struct {
+	Tid int
+}
+
+func (e *TsyncError) Error() string {
+	if e.Tid == 0 {
+		return "error synchronizing seccomp filter to the threads of the process"
+	}
+	return fmt.Sprintf("error synchronizing seccomp filter to thread %d of the process", e.Tid)
+}
+
+// InitResult reports the rules of a profile that are not part of the
+// loaded filter.
+type InitResult struct {
+	// Skipped lists by architecture the syscalls that do not exist
+	// there, common for profiles written for several architectures.
+	Skipped map[Arch][]string `json:"skipped,omitempty"`
+	// Unknown lists the names that are no syscall on any architecture,
+	// usually because they are misspelled.
+	Unknown []string `json:"unknown,omitempty"`
+}
+
+// newInitResult checks the syscalls of config on every architecture
+// filtered, exists reports if a syscall exists on an architecture and
+// known if it exists on any.
+func newInitResult(config *Seccomp, arches []Arch, exists func(arch Arch, name string) bool, known func(name string) bool) *InitResult {
+	result := &InitResult{}
+	seen := make(map[string]bool)
+	for _, call := range config.Syscalls {
+		if call == nil || call.Name == "" || seen[call.Name] {
+			continue
+		}
+		seen[call.Name] = true
+
+		var missing []Arch
+		for _, arch := range arches {
+			if !exists(arch, call.Name) {
+				missing = append(missing, arch)
+			}
+		}
+		if len(missing) == len(arches) && !known(call.Name) {
+			result.Unknown = append(result.Unknown, call.Name)
+			continue
+		}
+		for _, arch := range missing {
+			if result.Skipped == nil {
+				result.Skipped = make(map[Arch][]string)
+			}
+			result.Skipped[arch] = append(result.Skipped[arch], call.Name)
+		}
+	}
+	return result
+}
+
+// check fails strict loads of profiles with unknown syscalls.
+func (r *InitResult) check(strict bool) error {
+	if strict && len(r.Unknown) > 0 {
+		return fmt.Errorf("unknown syscalls %s", strings.Join(r.Unknown, ", "))
+	}
+	return nil
+}
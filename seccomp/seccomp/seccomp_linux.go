@@ -4,7 +4,10 @@ package seccomp
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"syscall"
+	"unsafe"
 
 	libseccomp "github.com/seccomp/libseccomp-golang"
 )
@@ -15,93 +18,150 @@ var (
 	actKill  = libseccomp.ActKill
 	actTrace = libseccomp.ActTrace.SetReturnCode(int16(syscall.EPERM))
 	actErrno = libseccomp.ActErrno.SetReturnCode(int16(syscall.EPERM))
-
-	// SeccompModeFilter refers to the syscall argument SECCOMP_MODE_FILTER.
-	SeccompModeFilter = uintptr(2)
+	actLog   = libseccomp.ActLog
 )
 
-// Filters given syscalls in a container, preventing them from being used
-// Started in the container init process, and carried over to all child processes
-// Setns calls, however, require a separate invocation, as they are not children
-// of the init until they join the namespace
-func InitSeccomp(config *Seccomp) error {
+// DefaultBackend is the Backend of InitSeccomp.
+const DefaultBackend = BackendLibseccomp
+
+// initLibseccomp loads config with libseccomp.
+func initLibseccomp(config *Seccomp, strict, tsync bool) (*InitResult, error) {
 	if config == nil {
-		return fmt.Errorf("cannot initialize Seccomp - nil config passed")
+		return nil, fmt.Errorf("cannot initialize Seccomp - nil config passed")
 	}
 
-	defaultAction, err := getAction(config.DefaultAction)
+	defaultAction, err := getAction(config.DefaultAction, config.DefaultErrnoRet)
 	if err != nil {
-		fmt.Println(config.DefaultAction)
-		return fmt.Errorf("error initializing seccomp - invalid default action")
+		return nil, fmt.Errorf("error initializing seccomp - invalid default action")
 	}
 
 	filter, err := libseccomp.NewFilter(defaultAction)
 	if err != nil {
-		return fmt.Errorf("error creating filter: %s", err)
+		return nil, fmt.Errorf("error creating filter: %s", err)
+	}
+
+	scmpNative, err := libseccomp.GetNativeArch()
+	if err != nil {
+		return nil, fmt.Errorf("error getting native architecture: %s", err)
 	}
+	arches := []Arch{Arch(scmpNative.String())}
+	scmpArches := []libseccomp.ScmpArch{scmpNative}
 
 	// Add extra architectures
 	for _, arch := range config.Architectures {
-		scmpArch, err := libseccomp.GetArchFromString(arch)
+		scmpArch, err := libseccomp.GetArchFromString(string(arch))
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if err := filter.AddArch(scmpArch); err != nil {
-			return err
+			return nil, err
+		}
+		if !inArches(arches, arch) {
+			arches = append(arches, arch)
+			scmpArches = append(scmpArches, scmpArch)
 		}
 	}
 
+	// Syscalls missing on an architecture resolve to negative pseudo
+	// syscalls there, only names libseccomp does not know fail.
+	result := newInitResult(config, arches, func(arch Arch, name string) bool {
+		for i := range arches {
+			if arches[i] == arch {
+				nr, err := libseccomp.GetSyscallFromNameByArch(name, scmpArches[i])
+				return err == nil && nr >= 0
+			}
+		}
+		return false
+	}, func(name string) bool {
+		_, err := libseccomp.GetSyscallFromName(name)
+		return err == nil
+	})
+	if err = result.check(strict); err != nil {
+		return result, fmt.Errorf("error initializing seccomp - %s", err)
+	}
+
 	// Unset no new privs bit
 	if err := filter.SetNoNewPrivsBit(false); err != nil {
-		return fmt.Errorf("error setting no new privileges: %s", err)
+		return result, fmt.Errorf("error setting no new privileges: %s", err)
 	}
 
 	// Add a rule for each syscall
 	for _, call := range config.Syscalls {
 		if call == nil {
-			return fmt.Errorf("encountered nil syscall while initializing Seccomp")
+			return result, fmt.Errorf("encountered nil syscall while initializing Seccomp")
 		}
 
-		if err = matchCall(filter, call); err != nil {
-			return err
+		if err = matchCall(filter, call, arches); err != nil {
+			return result, err
+		}
+	}
+
+	// libseccomp syncs threads when the kernel supports it but does not
+	// tell which failed, the program it builds is loaded natively then.
+	if tsync {
+		prog, err := exportBPF(filter)
+		if err != nil {
+			return result, fmt.Errorf("error exporting seccomp filter: %s", err)
 		}
+		return result, loadFilter(prog, true)
 	}
 
 	if err = filter.Load(); err != nil {
-		return fmt.Errorf("error loading seccomp filter into kernel: %s", err)
+		return result, fmt.Errorf("error loading seccomp filter into kernel: %s", err)
 	}
 
-	return nil
+	return result, nil
 }
 
-// IsEnabled returns if the kernel has been configured to support seccomp.
-func IsEnabled() bool {
-	// Check if Seccomp is supported, via CONFIG_SECCOMP.
-	_, _, err := syscall.RawSyscall(syscall.SYS_PRCTL, syscall.PR_GET_SECCOMP, 0, 0)
-	if err != syscall.EINVAL {
-		// Make sure the kernel has CONFIG_SECCOMP_FILTER.
-		_, _, err = syscall.RawSyscall(syscall.SYS_PRCTL, syscall.PR_SET_SECCOMP, SeccompModeFilter, 0)
-		if err != syscall.EINVAL {
-			return true
-		}
+// exportBPF returns the program libseccomp builds for filter.
+func exportBPF(filter *libseccomp.ScmpFilter) ([]SockFilter, error) {
+	f, err := ioutil.TempFile("", "seccomp-bpf")
+	if err != nil {
+		return nil, err
 	}
-	return false
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err = filter.ExportBPF(f); err != nil {
+		return nil, err
+	}
+	raw, err := ioutil.ReadFile(f.Name())
+	if err != nil {
+		return nil, err
+	}
+	size := int(unsafe.Sizeof(SockFilter{}))
+	if len(raw) == 0 || len(raw)%size != 0 || len(raw)/size > maxInsns {
+		return nil, fmt.Errorf("exported program of %d bytes is invalid", len(raw))
+	}
+	prog := make([]SockFilter, len(raw)/size)
+	copy((*[maxInsns * 8]byte)(unsafe.Pointer(&prog[0]))[:len(raw)], raw)
+	return prog, nil
 }
 
-// Convert Libcontainer Action to Libseccomp ScmpAction
-func getAction(act Action) (libseccomp.ScmpAction, error) {
+// Convert Libcontainer Action to Libseccomp ScmpAction, errnoRet
+// overrides the errno of Errno and the message of Trace actions.
+func getAction(act Action, errnoRet *uint) (libseccomp.ScmpAction, error) {
 	switch act {
 	case Kill:
 		return actKill, nil
 	case Errno:
+		if errnoRet != nil {
+			return libseccomp.ActErrno.SetReturnCode(int16(*errnoRet)), nil
+		}
 		return actErrno, nil
 	case Trap:
 		return actTrap, nil
 	case Allow:
 		return actAllow, nil
 	case Trace:
+		if errnoRet != nil {
+			return libseccomp.ActTrace.SetReturnCode(int16(*errnoRet)), nil
+		}
 		return actTrace, nil
+	case Log:
+		return actLog, nil
+	case KillProcess, UserNotif:
+		return libseccomp.ActInvalid, fmt.Errorf("action %s is not supported by the libseccomp backend, use the native backend", act)
 	default:
 		return libseccomp.ActInvalid, fmt.Errorf("invalid action, cannot use in rule")
 	}
@@ -129,14 +189,31 @@ func getOperator(op Operator) (libseccomp.ScmpCompareOp, error) {
 	}
 }
 
-// Convert Libcontainer Arg to Libseccomp ScmpCondition
-func getCondition(arg *Arg) (libseccomp.ScmpCondition, error) {
+// Convert Libcontainer Arg to Libseccomp ScmpCondition, libseccomp
+// compares the same values on every architecture of the filter so
+// symbolic values must resolve alike on all of arches
+func getCondition(arg *Arg, arches []Arch) (libseccomp.ScmpCondition, error) {
 	cond := libseccomp.ScmpCondition{}
 
 	if arg == nil {
 		return cond, fmt.Errorf("cannot convert nil to syscall condition")
 	}
 
+	resolved, err := arg.resolve(arches[0])
+	if err != nil {
+		return cond, err
+	}
+	for _, arch := range arches[1:] {
+		other, err := arg.resolve(arch)
+		if err != nil {
+			return cond, err
+		}
+		if other.Value != resolved.Value || other.ValueTwo != resolved.ValueTwo {
+			return cond, fmt.Errorf("argument %d differs between architectures %s and %s, use the native backend", arg.Index, arches[0], arch)
+		}
+	}
+	arg = resolved
+
 	op, err := getOperator(arg.Op)
 	if err != nil {
 		return cond, err
@@ -145,8 +222,8 @@ func getCondition(arg *Arg) (libseccomp.ScmpCondition, error) {
 	return libseccomp.MakeCondition(arg.Index, op, arg.Value, arg.ValueTwo)
 }
 
-// Add a rule to match a single syscall
-func matchCall(filter *libseccomp.ScmpFilter, call *Syscall) error {
+// Add a rule to match a single syscall on arches
+func matchCall(filter *libseccomp.ScmpFilter, call *Syscall, arches []Arch) error {
 	if call == nil || filter == nil {
 		return fmt.Errorf("cannot use nil as syscall to block")
 	}
@@ -156,14 +233,14 @@ func matchCall(filter *libseccomp.ScmpFilter, call *Syscall) error {
 	}
 
 	// If we can't resolve the syscall, assume it's not supported on this kernel
-	// Ignore it, don't error out
+	// Ignore it, don't error out, InitResult reports it
 	callNum, err := libseccomp.GetSyscallFromName(call.Name)
 	if err != nil {
 		return nil
 	}
 
 	// Convert the call's action to the libseccomp equivalent
-	callAct, err := getAction(call.Action)
+	callAct, err := getAction(call.Action, call.ErrnoRet)
 	if err != nil {
 		return err
 	}
@@ -178,7 +255,7 @@ func matchCall(filter *libseccomp.ScmpFilter, call *Syscall) error {
 		conditions := []libseccomp.ScmpCondition{}
 
 		for _, cond := range call.Args {
-			newCond, err := getCondition(cond)
+			newCond, err := getCondition(cond, arches)
 			if err != nil {
 				return err
 			}
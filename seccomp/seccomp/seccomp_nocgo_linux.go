@@ -0,0 +1,17 @@
+// +build linux,!cgo
+
+package seccomp
+
+import (
+	"errors"
+)
+
+var ErrLibseccompNotAvailable = errors.New("seccomp: libseccomp backend requires cgo")
+
+// DefaultBackend is the Backend of InitSeccomp.
+const DefaultBackend = BackendNative
+
+// libseccomp is not available without cgo.
+func initLibseccomp(config *Seccomp, strict, tsync bool) (*InitResult, error) {
+	return nil, ErrLibseccompNotAvailable
+}
@@ -1,21 +1,27 @@
-// +build !linux !cgo
+// +build !linux
 
 package seccomp
 
 import (
 	"errors"
-
-	"github.com/opencontainers/runc/libcontainer/configs"
 )
 
 var ErrSeccompNotEnabled = errors.New("seccomp: config provided but seccomp not supported")
 
+// DefaultBackend is the Backend of InitSeccomp.
+const DefaultBackend = BackendNative
+
+// Seccomp not supported, do nothing
+func InitSeccomp(config *Seccomp) (*InitResult, error) {
+	return InitSeccompWith(config, InitOptions{})
+}
+
 // Seccomp not supported, do nothing
-func InitSeccomp(config *configs.Seccomp) error {
+func InitSeccompWith(config *Seccomp, opts InitOptions) (*InitResult, error) {
 	if config != nil {
-		return ErrSeccompNotEnabled
+		return nil, ErrSeccompNotEnabled
 	}
-	return nil
+	return nil, nil
 }
 
 // IsEnabled returns false, because it is not supported.
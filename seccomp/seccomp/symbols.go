@@ -0,0 +1,254 @@
+package seccomp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Arguments may be given in profiles by symbolic expressions, names of
+// flagTables and integers ORed with "|" like "O_WRONLY|O_CREAT", which
+// are resolved for each architecture filtered since the values of some
+// flags differ between architectures.
+
+// flagTable returns the values of the symbolic names of arch.
+func flagTable(arch Arch) map[string]uint64 {
+	if arch == "x32" {
+		arch = "amd64"
+	}
+	return flagTables[arch]
+}
+
+// ParseArgValue resolves a symbolic argument expression for arch.
+func ParseArgValue(expr string, arch Arch) (uint64, error) {
+	table := flagTable(arch)
+	if table == nil {
+		return 0, fmt.Errorf("architecture %s has no symbolic argument values", arch)
+	}
+	var value uint64
+	for _, term := range strings.Split(expr, "|") {
+		term = strings.TrimSpace(term)
+		if n, err := strconv.ParseUint(term, 0, 64); err == nil {
+			value |= n
+			continue
+		}
+		n, ok := table[term]
+		if !ok {
+			return 0, fmt.Errorf("unknown argument value %s on %s", term, arch)
+		}
+		value |= n
+	}
+	return value, nil
+}
+
+// resolve returns arg with the values of its symbolic expressions on
+// arch.
+func (a *Arg) resolve(arch Arch) (*Arg, error) {
+	if a == nil || (a.ValueName == "" && a.ValueTwoName == "") {
+		return a, nil
+	}
+	resolved := *a
+	var err error
+	if a.ValueName != "" {
+		if resolved.Value, err = ParseArgValue(a.ValueName, arch); err != nil {
+			return nil, err
+		}
+	}
+	if a.ValueTwoName != "" {
+		if resolved.ValueTwo, err = ParseArgValue(a.ValueTwoName, arch); err != nil {
+			return nil, err
+		}
+	}
+	return &resolved, nil
+}
+
+// argValue reads a value of an argument, either an integer or a
+// symbolic expression. Expressions are kept unresolved until the
+// architectures filtered are known, their names need to exist on one.
+func argValue(data json.RawMessage) (uint64, string, error) {
+	if len(data) == 0 {
+		return 0, "", nil
+	}
+	var expr string
+	if err := json.Unmarshal(data, &expr); err != nil {
+		var n uint64
+		if err = json.Unmarshal(data, &n); err != nil {
+			return 0, "", fmt.Errorf("%s is not a valid argument value", data)
+		}
+		return n, "", nil
+	}
+	for _, term := range strings.Split(expr, "|") {
+		term = strings.TrimSpace(term)
+		if _, err := strconv.ParseUint(term, 0, 64); err != nil && !knownArgValue(term) {
+			return 0, "", fmt.Errorf("unknown argument value %s", term)
+		}
+	}
+	return 0, expr, nil
+}
+
+// knownArgValue reports if name is a flag of any architecture.
+func knownArgValue(name string) bool {
+	for _, table := range flagTables {
+		if _, ok := table[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// argFlags is how the values of an argument are named: exactly by the
+// names of prefix for the bits of enum, ORed for the other bits.
+type argFlags struct {
+	prefix string
+	enum   uint64
+}
+
+var (
+	cloneFlags  = argFlags{"CLONE_", 0}
+	familyFlags = argFlags{"AF_", ^uint64(0)}
+	socketFlags = argFlags{"SOCK_", 0xf}
+	openFlags   = argFlags{"O_", 0x3}
+	protFlags   = argFlags{"PROT_", 0}
+	mapFlags    = argFlags{"MAP_", 0xf}
+)
+
+// symbolicArgs are the arguments written symbolically in profiles, by
+// syscall and argument index.
+var symbolicArgs = map[string]map[uint]argFlags{
+	"clone":             {0: cloneFlags},
+	"unshare":           {0: cloneFlags},
+	"setns":             {1: cloneFlags},
+	"socket":            {0: familyFlags, 1: socketFlags},
+	"socketpair":        {0: familyFlags, 1: socketFlags},
+	"accept4":           {3: socketFlags},
+	"open":              {1: openFlags},
+	"openat":            {2: openFlags},
+	"open_by_handle_at": {2: openFlags},
+	"pipe2":             {1: openFlags},
+	"dup3":              {2: openFlags},
+	"mmap":              {2: protFlags, 3: mapFlags},
+	"mmap2":             {2: protFlags, 3: mapFlags},
+	"mprotect":          {2: protFlags},
+	"pkey_mprotect":     {2: protFlags},
+}
+
+// Names of flagTables which are aliases of other names, never written.
+var flagAliases = map[string]bool{
+	"AF_FILE":  true,
+	"AF_LOCAL": true,
+	"AF_ROUTE": true,
+	"MAP_ANON": true,
+	"MAP_FILE": true,
+	"O_FSYNC":  true,
+	"O_NDELAY": true,
+	"O_RSYNC":  true,
+}
+
+// Names of a zero value, flags like O_LARGEFILE are zero on some
+// architectures.
+var zeroNames = map[string]bool{
+	"AF_UNSPEC": true,
+	"O_RDONLY":  true,
+	"PROT_NONE": true,
+}
+
+// format names value, bits without a name are written as an integer.
+func (f argFlags) format(value uint64, arch Arch) string {
+	var enum, flags []string
+	names := make(map[uint64]string)
+	for name, v := range flagTable(arch) {
+		if !strings.HasPrefix(name, f.prefix) || flagAliases[name] || (v == 0 && !zeroNames[name]) {
+			continue
+		}
+		if v&^f.enum == 0 {
+			if other, ok := names[v]; !ok || name < other {
+				names[v] = name
+			}
+		} else if v&f.enum == 0 {
+			flags = append(flags, name)
+		}
+	}
+
+	// Zero is only named alone, O_CREAT rather than O_RDONLY|O_CREAT
+	// which reads wrong as a mask.
+	var terms []string
+	if name, ok := names[value&f.enum]; ok && (value == 0 || value&f.enum != 0) {
+		enum = append(enum, name)
+	} else if value&f.enum != 0 {
+		enum = append(enum, fmt.Sprintf("%#x", value&f.enum))
+	}
+	terms = append(terms, enum...)
+
+	// Flags of more bits first, O_SYNC rather than O_DSYNC and the
+	// rest of it.
+	table := flagTable(arch)
+	sort.Slice(flags, func(i, j int) bool {
+		a, b := table[flags[i]], table[flags[j]]
+		if bits.OnesCount64(a) != bits.OnesCount64(b) {
+			return bits.OnesCount64(a) > bits.OnesCount64(b)
+		}
+		if a != b {
+			return a < b
+		}
+		return flags[i] < flags[j]
+	})
+	rest := value &^ f.enum
+	var set []string
+	for _, name := range flags {
+		if v := table[name]; v != 0 && v&rest == v {
+			set = append(set, name)
+			rest &^= v
+		}
+	}
+	sort.Slice(set, func(i, j int) bool { return table[set[i]] < table[set[j]] })
+	terms = append(terms, set...)
+	if rest != 0 {
+		terms = append(terms, fmt.Sprintf("%#x", rest))
+	}
+	if len(terms) == 0 {
+		return "0"
+	}
+	return strings.Join(terms, "|")
+}
+
+// symbolize names the values of the arguments of call compared for
+// equality, unless they already are, by the flags of the first of
+// arches. Names are only given to values they resolve to on all of
+// arches, the others are kept as integers.
+func symbolize(call string, arg *Arg, arches ...Arch) *Arg {
+	arch := arches[0]
+	index := arg.Index
+	// clone takes its stack before its flags on s390x.
+	if call == "clone" && arch == "s390x" && index < 2 {
+		index = 1 - index
+	}
+	f, ok := symbolicArgs[call][index]
+	if !ok || flagTable(arch) == nil || (arg.Op != EqualTo && arg.Op != NotEqualTo && arg.Op != MaskEqualTo) {
+		return arg
+	}
+	named := *arg
+	if named.ValueName == "" {
+		named.ValueName = symbolic(f.format(arg.Value, arch), arg.Value, arches)
+	}
+	if named.ValueTwoName == "" && arg.Op == MaskEqualTo {
+		named.ValueTwoName = symbolic(f.format(arg.ValueTwo, arch), arg.ValueTwo, arches)
+	}
+	return &named
+}
+
+// symbolic returns expr unless no name is in it, or it does not resolve
+// to value on one of arches.
+func symbolic(expr string, value uint64, arches []Arch) string {
+	if _, err := strconv.ParseUint(expr, 0, 64); err == nil {
+		return ""
+	}
+	for _, arch := range arches {
+		if n, err := ParseArgValue(expr, arch); err != nil || n != value {
+			return ""
+		}
+	}
+	return expr
+}
@@ -0,0 +1,128 @@
+package seccomp
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSymbolicArgsResolvedPerArch(t *testing.T) {
+	// O_CREAT is 0x40 on amd64 and 0x100 on mips, whatever the
+	// architecture the profile is parsed on.
+	var config Seccomp
+	profile := `{
+		"defaultAction": "SCMP_ACT_ALLOW",
+		"architectures": ["SCMP_ARCH_X86_64", "SCMP_ARCH_MIPS"],
+		"syscalls": [{
+			"name": "openat",
+			"action": "SCMP_ACT_ERRNO",
+			"args": [{"index": 2, "value": "O_CREAT", "value_two": "O_CREAT", "op": "SCMP_CMP_MASKED_EQ"}]
+		}]
+	}`
+	if err := json.Unmarshal([]byte(profile), &config); err != nil {
+		t.Fatal(err)
+	}
+	prog, err := Compile(&config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		arch   Arch
+		flags  uint64
+		action Action
+	}{
+		{"amd64", 0x40, Errno},
+		{"amd64", 0x100, Allow},
+		{"mips", 0x100, Errno},
+		{"mips", 0x40, Allow},
+	}
+	for _, test := range tests {
+		data, err := NewSeccompData(test.arch, "openat", 0, 0, test.flags)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ret, err := Evaluate(prog, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if action, _ := ReturnAction(ret); action != test.action {
+			t.Errorf("openat with flags %#x on %s is %v, expected %v", test.flags, test.arch, action, test.action)
+		}
+	}
+}
+
+func TestUnknownArgValue(t *testing.T) {
+	var arg Arg
+	if err := json.Unmarshal([]byte(`{"index": 2, "value": "O_CREAT|O_NOSUCH", "op": "SCMP_CMP_EQ"}`), &arg); err == nil {
+		t.Error("unknown argument value O_NOSUCH was accepted")
+	}
+}
+
+func TestMarshalKeepsArgValues(t *testing.T) {
+	// 0x100 is O_CREAT on mips, but O_NOCTTY on amd64, so it must not be
+	// written by a name of either.
+	config := Seccomp{
+		DefaultAction: Allow,
+		Architectures: []Arch{"mips"},
+		Syscalls: []*Syscall{{
+			Name:   "openat",
+			Action: Errno,
+			Args: []*Arg{
+				{Index: 2, Value: 0x100, ValueTwo: 0x100, Op: MaskEqualTo},
+				{Index: 1, Value: 0, Op: NotEqualTo},
+			},
+		}},
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var read Seccomp
+	if err = json.Unmarshal(data, &read); err != nil {
+		t.Fatal(err)
+	}
+	prog, err := Compile(&read)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		flags  uint64
+		action Action
+	}{
+		{0x100, Errno},
+		{0x40, Allow},
+	}
+	for _, test := range tests {
+		seccompData, err := NewSeccompData("mips", "openat", 0, 1, test.flags)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ret, err := Evaluate(prog, seccompData)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if action, _ := ReturnAction(ret); action != test.action {
+			t.Errorf("openat with flags %#x on mips is %v after %s, expected %v", test.flags, action, data, test.action)
+		}
+	}
+}
+
+func TestMarshalNamesCommonArgValues(t *testing.T) {
+	// O_WRONLY is 1 on all architectures.
+	config := Seccomp{
+		DefaultAction: Allow,
+		Architectures: []Arch{"mips"},
+		Syscalls: []*Syscall{{
+			Name:   "openat",
+			Action: Errno,
+			Args:   []*Arg{{Index: 2, Value: 1, ValueTwo: 3, Op: MaskEqualTo}},
+		}},
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"value":"O_WRONLY"`) {
+		t.Errorf("O_WRONLY is not written by its name in %s", data)
+	}
+}
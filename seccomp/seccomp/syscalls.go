@@ -0,0 +1,37 @@
+package seccomp
+
+import "sync"
+
+var (
+	syscallNamesOnce sync.Once
+	syscallNames     map[Arch]map[uint64]string
+)
+
+// ResolveSyscall names the syscall nr of the architecture identified by
+// its AUDIT_ARCH value, as the kernel reports syscalls to filters and
+// tracers. It is false for architectures and syscalls without a table.
+func ResolveSyscall(audit uint32, nr uint64) (Arch, string, bool) {
+	syscallNamesOnce.Do(func() {
+		syscallNames = make(map[Arch]map[uint64]string)
+		for arch, table := range syscallTables {
+			names := make(map[uint64]string, len(table))
+			for name, nr := range table {
+				names[uint64(nr)] = name
+			}
+			syscallNames[arch] = names
+		}
+	})
+
+	for arch, info := range archInfos {
+		if info.audit != audit {
+			continue
+		}
+		// x32 shares the audit value of amd64.
+		if (arch == "x32") != (nr&x32SyscallBit != 0) {
+			continue
+		}
+		name, ok := syscallNames[arch][nr]
+		return arch, name, ok
+	}
+	return "", "", false
+}
@@ -0,0 +1,118 @@
+package seccomp
+
+import "testing"
+
+func TestSyscallNumbers(t *testing.T) {
+	tests := []struct {
+		arch Arch
+		name string
+		nr   uint64
+		ok   bool
+	}{
+		{"ppc64le", "read", 3, true},
+		{"ppc64le", "openat", 286, true},
+		{"ppc64le", "clone", 120, true},
+		{"ppc64le", "getrandom", 359, true},
+		{"ppc64le", "_newselect", 142, true},
+		{"ppc64le", "arch_prctl", 0, false},
+		{"ppc64le", "mmap2", 0, false},
+		{"s390x", "read", 3, true},
+		{"s390x", "openat", 288, true},
+		{"s390x", "clone", 120, true},
+		{"s390x", "getrandom", 349, true},
+		{"s390x", "select", 142, true},
+		{"s390x", "_newselect", 0, false},
+		{"s390x", "mmap2", 0, false},
+		{"riscv64", "read", 63, true},
+		{"riscv64", "openat", 56, true},
+		{"riscv64", "clone", 220, true},
+		{"riscv64", "getrandom", 278, true},
+		{"riscv64", "open", 0, false},
+		{"riscv64", "fork", 0, false},
+		{"riscv64", "renameat", 0, false},
+	}
+	for _, test := range tests {
+		nr, ok := syscallTables[test.arch][test.name]
+		if ok != test.ok || uint64(nr) != test.nr {
+			t.Errorf("%s on %s is %d %v, expected %d %v", test.name, test.arch, nr, ok, test.nr, test.ok)
+		}
+		if !test.ok {
+			continue
+		}
+		arch, name, ok := ResolveSyscall(archInfos[test.arch].audit, test.nr)
+		if !ok || arch != test.arch || name != test.name {
+			t.Errorf("syscall %d of %s resolves to %s on %s", test.nr, test.arch, name, arch)
+		}
+	}
+}
+
+func TestCompileArches(t *testing.T) {
+	config := &Seccomp{
+		DefaultAction: Allow,
+		Architectures: []Arch{"ppc64le", "s390x", "riscv64"},
+		Syscalls: []*Syscall{
+			{Name: "openat", Action: Errno},
+			{Name: "socket", Action: Errno, Args: []*Arg{{Index: 0, Value: 10, Op: EqualTo}}},
+		},
+	}
+	prog, err := Compile(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The AUDIT_ARCH_* values of the kernel, spelled out rather than
+	// taken from archInfos.
+	tests := []struct {
+		audit  uint32
+		nr     int32
+		args   []uint64
+		action Action
+	}{
+		{0xc0000015, 286, nil, Errno},
+		{0xc0000015, 3, nil, Allow},
+		{0x80000016, 288, nil, Errno},
+		{0x80000016, 3, nil, Allow},
+		{0xc00000f3, 56, nil, Errno},
+		{0xc00000f3, 63, nil, Allow},
+		// Both halves of 64 bit arguments are compared, in the byte
+		// order of the architecture.
+		{0x80000016, 359, []uint64{10}, Errno},
+		{0x80000016, 359, []uint64{2}, Allow},
+		{0x80000016, 359, []uint64{1<<32 | 10}, Allow},
+		{0xc0000015, 326, []uint64{10}, Errno},
+		{0xc0000015, 326, []uint64{1<<32 | 10}, Allow},
+		{0xc00000f3, 198, []uint64{10}, Errno},
+		// ppc64 big endian is not filtered.
+		{0x80000015, 286, nil, Kill},
+	}
+	for _, test := range tests {
+		data := &SeccompData{Nr: test.nr, Arch: test.audit}
+		copy(data.Args[:], test.args)
+		ret, err := Evaluate(prog, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if action, _ := ReturnAction(ret); action != test.action {
+			t.Errorf("syscall %d%v of %#x is %v, expected %v", test.nr, test.args, test.audit, action, test.action)
+		}
+	}
+}
+
+func TestSeccompDataByteOrder(t *testing.T) {
+	data, err := NewSeccompData("s390x", "socket", 0x0102030405060708)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := data.marshal()
+	want := []byte{0x00, 0x00, 0x01, 0x67, 0x80, 0x00, 0x00, 0x16}
+	for i, b := range want {
+		if raw[i] != b {
+			t.Fatalf("nr and arch of s390x are laid out % x, expected % x", raw[:8], want)
+		}
+	}
+	for i := 0; i < 8; i++ {
+		if raw[dataArgs+i] != byte(i+1) {
+			t.Fatalf("argument of s390x is laid out % x, expected big endian", raw[dataArgs:dataArgs+8])
+		}
+	}
+}
@@ -0,0 +1,1462 @@
+// Code generated by mkflags.go; DO NOT EDIT.
+
+package seccomp
+
+// flagTables are the values of the symbolic names of syscall arguments
+// of each architecture.
+var flagTables = map[Arch]map[string]uint64{
+	"x86": {
+		"AF_ALG":               0x26,
+		"AF_APPLETALK":         0x5,
+		"AF_ASH":               0x12,
+		"AF_ATMPVC":            0x8,
+		"AF_ATMSVC":            0x14,
+		"AF_AX25":              0x3,
+		"AF_BLUETOOTH":         0x1f,
+		"AF_BRIDGE":            0x7,
+		"AF_CAIF":              0x25,
+		"AF_CAN":               0x1d,
+		"AF_ECONET":            0x13,
+		"AF_FILE":              0x1,
+		"AF_IB":                0x1b,
+		"AF_IEEE802154":        0x24,
+		"AF_INET":              0x2,
+		"AF_INET6":             0xa,
+		"AF_IPX":               0x4,
+		"AF_IRDA":              0x17,
+		"AF_ISDN":              0x22,
+		"AF_IUCV":              0x20,
+		"AF_KCM":               0x29,
+		"AF_KEY":               0xf,
+		"AF_LLC":               0x1a,
+		"AF_LOCAL":             0x1,
+		"AF_MPLS":              0x1c,
+		"AF_NETBEUI":           0xd,
+		"AF_NETLINK":           0x10,
+		"AF_NETROM":            0x6,
+		"AF_NFC":               0x27,
+		"AF_PACKET":            0x11,
+		"AF_PHONET":            0x23,
+		"AF_PPPOX":             0x18,
+		"AF_QIPCRTR":           0x2a,
+		"AF_RDS":               0x15,
+		"AF_ROSE":              0xb,
+		"AF_ROUTE":             0x10,
+		"AF_RXRPC":             0x21,
+		"AF_SECURITY":          0xe,
+		"AF_SMC":               0x2b,
+		"AF_SNA":               0x16,
+		"AF_TIPC":              0x1e,
+		"AF_UNIX":              0x1,
+		"AF_UNSPEC":            0x0,
+		"AF_VSOCK":             0x28,
+		"AF_WANPIPE":           0x19,
+		"AF_X25":               0x9,
+		"AF_XDP":               0x2c,
+		"CLONE_CHILD_CLEARTID": 0x200000,
+		"CLONE_CHILD_SETTID":   0x1000000,
+		"CLONE_CLEAR_SIGHAND":  0x100000000,
+		"CLONE_DETACHED":       0x400000,
+		"CLONE_FILES":          0x400,
+		"CLONE_FS":             0x200,
+		"CLONE_INTO_CGROUP":    0x200000000,
+		"CLONE_IO":             0x80000000,
+		"CLONE_NEWCGROUP":      0x2000000,
+		"CLONE_NEWIPC":         0x8000000,
+		"CLONE_NEWNET":         0x40000000,
+		"CLONE_NEWNS":          0x20000,
+		"CLONE_NEWPID":         0x20000000,
+		"CLONE_NEWTIME":        0x80,
+		"CLONE_NEWUSER":        0x10000000,
+		"CLONE_NEWUTS":         0x4000000,
+		"CLONE_PARENT":         0x8000,
+		"CLONE_PARENT_SETTID":  0x100000,
+		"CLONE_PIDFD":          0x1000,
+		"CLONE_PTRACE":         0x2000,
+		"CLONE_SETTLS":         0x80000,
+		"CLONE_SIGHAND":        0x800,
+		"CLONE_SYSVSEM":        0x40000,
+		"CLONE_THREAD":         0x10000,
+		"CLONE_UNTRACED":       0x800000,
+		"CLONE_VFORK":          0x4000,
+		"CLONE_VM":             0x100,
+		"MAP_32BIT":            0x40,
+		"MAP_ANON":             0x20,
+		"MAP_ANONYMOUS":        0x20,
+		"MAP_DENYWRITE":        0x800,
+		"MAP_EXECUTABLE":       0x1000,
+		"MAP_FILE":             0x0,
+		"MAP_FIXED":            0x10,
+		"MAP_FIXED_NOREPLACE":  0x100000,
+		"MAP_GROWSDOWN":        0x100,
+		"MAP_HUGETLB":          0x40000,
+		"MAP_LOCKED":           0x2000,
+		"MAP_NONBLOCK":         0x10000,
+		"MAP_NORESERVE":        0x4000,
+		"MAP_POPULATE":         0x8000,
+		"MAP_PRIVATE":          0x2,
+		"MAP_SHARED":           0x1,
+		"MAP_SHARED_VALIDATE":  0x3,
+		"MAP_STACK":            0x20000,
+		"MAP_SYNC":             0x80000,
+		"O_APPEND":             0x400,
+		"O_ASYNC":              0x2000,
+		"O_CLOEXEC":            0x80000,
+		"O_CREAT":              0x40,
+		"O_DIRECT":             0x4000,
+		"O_DIRECTORY":          0x10000,
+		"O_DSYNC":              0x1000,
+		"O_EXCL":               0x80,
+		"O_FSYNC":              0x101000,
+		"O_LARGEFILE":          0x8000,
+		"O_NDELAY":             0x800,
+		"O_NOATIME":            0x40000,
+		"O_NOCTTY":             0x100,
+		"O_NOFOLLOW":           0x20000,
+		"O_NONBLOCK":           0x800,
+		"O_PATH":               0x200000,
+		"O_RDONLY":             0x0,
+		"O_RDWR":               0x2,
+		"O_RSYNC":              0x101000,
+		"O_SYNC":               0x101000,
+		"O_TMPFILE":            0x410000,
+		"O_TRUNC":              0x200,
+		"O_WRONLY":             0x1,
+		"PROT_EXEC":            0x4,
+		"PROT_GROWSDOWN":       0x1000000,
+		"PROT_GROWSUP":         0x2000000,
+		"PROT_NONE":            0x0,
+		"PROT_READ":            0x1,
+		"PROT_WRITE":           0x2,
+		"SOCK_CLOEXEC":         0x80000,
+		"SOCK_DCCP":            0x6,
+		"SOCK_DGRAM":           0x2,
+		"SOCK_NONBLOCK":        0x800,
+		"SOCK_PACKET":          0xa,
+		"SOCK_RAW":             0x3,
+		"SOCK_RDM":             0x4,
+		"SOCK_SEQPACKET":       0x5,
+		"SOCK_STREAM":          0x1,
+	},
+	"amd64": {
+		"AF_ALG":               0x26,
+		"AF_APPLETALK":         0x5,
+		"AF_ASH":               0x12,
+		"AF_ATMPVC":            0x8,
+		"AF_ATMSVC":            0x14,
+		"AF_AX25":              0x3,
+		"AF_BLUETOOTH":         0x1f,
+		"AF_BRIDGE":            0x7,
+		"AF_CAIF":              0x25,
+		"AF_CAN":               0x1d,
+		"AF_ECONET":            0x13,
+		"AF_FILE":              0x1,
+		"AF_IB":                0x1b,
+		"AF_IEEE802154":        0x24,
+		"AF_INET":              0x2,
+		"AF_INET6":             0xa,
+		"AF_IPX":               0x4,
+		"AF_IRDA":              0x17,
+		"AF_ISDN":              0x22,
+		"AF_IUCV":              0x20,
+		"AF_KCM":               0x29,
+		"AF_KEY":               0xf,
+		"AF_LLC":               0x1a,
+		"AF_LOCAL":             0x1,
+		"AF_MPLS":              0x1c,
+		"AF_NETBEUI":           0xd,
+		"AF_NETLINK":           0x10,
+		"AF_NETROM":            0x6,
+		"AF_NFC":               0x27,
+		"AF_PACKET":            0x11,
+		"AF_PHONET":            0x23,
+		"AF_PPPOX":             0x18,
+		"AF_QIPCRTR":           0x2a,
+		"AF_RDS":               0x15,
+		"AF_ROSE":              0xb,
+		"AF_ROUTE":             0x10,
+		"AF_RXRPC":             0x21,
+		"AF_SECURITY":          0xe,
+		"AF_SMC":               0x2b,
+		"AF_SNA":               0x16,
+		"AF_TIPC":              0x1e,
+		"AF_UNIX":              0x1,
+		"AF_UNSPEC":            0x0,
+		"AF_VSOCK":             0x28,
+		"AF_WANPIPE":           0x19,
+		"AF_X25":               0x9,
+		"AF_XDP":               0x2c,
+		"CLONE_CHILD_CLEARTID": 0x200000,
+		"CLONE_CHILD_SETTID":   0x1000000,
+		"CLONE_CLEAR_SIGHAND":  0x100000000,
+		"CLONE_DETACHED":       0x400000,
+		"CLONE_FILES":          0x400,
+		"CLONE_FS":             0x200,
+		"CLONE_INTO_CGROUP":    0x200000000,
+		"CLONE_IO":             0x80000000,
+		"CLONE_NEWCGROUP":      0x2000000,
+		"CLONE_NEWIPC":         0x8000000,
+		"CLONE_NEWNET":         0x40000000,
+		"CLONE_NEWNS":          0x20000,
+		"CLONE_NEWPID":         0x20000000,
+		"CLONE_NEWTIME":        0x80,
+		"CLONE_NEWUSER":        0x10000000,
+		"CLONE_NEWUTS":         0x4000000,
+		"CLONE_PARENT":         0x8000,
+		"CLONE_PARENT_SETTID":  0x100000,
+		"CLONE_PIDFD":          0x1000,
+		"CLONE_PTRACE":         0x2000,
+		"CLONE_SETTLS":         0x80000,
+		"CLONE_SIGHAND":        0x800,
+		"CLONE_SYSVSEM":        0x40000,
+		"CLONE_THREAD":         0x10000,
+		"CLONE_UNTRACED":       0x800000,
+		"CLONE_VFORK":          0x4000,
+		"CLONE_VM":             0x100,
+		"MAP_32BIT":            0x40,
+		"MAP_ANON":             0x20,
+		"MAP_ANONYMOUS":        0x20,
+		"MAP_DENYWRITE":        0x800,
+		"MAP_EXECUTABLE":       0x1000,
+		"MAP_FILE":             0x0,
+		"MAP_FIXED":            0x10,
+		"MAP_FIXED_NOREPLACE":  0x100000,
+		"MAP_GROWSDOWN":        0x100,
+		"MAP_HUGETLB":          0x40000,
+		"MAP_LOCKED":           0x2000,
+		"MAP_NONBLOCK":         0x10000,
+		"MAP_NORESERVE":        0x4000,
+		"MAP_POPULATE":         0x8000,
+		"MAP_PRIVATE":          0x2,
+		"MAP_SHARED":           0x1,
+		"MAP_SHARED_VALIDATE":  0x3,
+		"MAP_STACK":            0x20000,
+		"MAP_SYNC":             0x80000,
+		"O_APPEND":             0x400,
+		"O_ASYNC":              0x2000,
+		"O_CLOEXEC":            0x80000,
+		"O_CREAT":              0x40,
+		"O_DIRECT":             0x4000,
+		"O_DIRECTORY":          0x10000,
+		"O_DSYNC":              0x1000,
+		"O_EXCL":               0x80,
+		"O_FSYNC":              0x101000,
+		"O_LARGEFILE":          0x0,
+		"O_NDELAY":             0x800,
+		"O_NOATIME":            0x40000,
+		"O_NOCTTY":             0x100,
+		"O_NOFOLLOW":           0x20000,
+		"O_NONBLOCK":           0x800,
+		"O_PATH":               0x200000,
+		"O_RDONLY":             0x0,
+		"O_RDWR":               0x2,
+		"O_RSYNC":              0x101000,
+		"O_SYNC":               0x101000,
+		"O_TMPFILE":            0x410000,
+		"O_TRUNC":              0x200,
+		"O_WRONLY":             0x1,
+		"PROT_EXEC":            0x4,
+		"PROT_GROWSDOWN":       0x1000000,
+		"PROT_GROWSUP":         0x2000000,
+		"PROT_NONE":            0x0,
+		"PROT_READ":            0x1,
+		"PROT_WRITE":           0x2,
+		"SOCK_CLOEXEC":         0x80000,
+		"SOCK_DCCP":            0x6,
+		"SOCK_DGRAM":           0x2,
+		"SOCK_NONBLOCK":        0x800,
+		"SOCK_PACKET":          0xa,
+		"SOCK_RAW":             0x3,
+		"SOCK_RDM":             0x4,
+		"SOCK_SEQPACKET":       0x5,
+		"SOCK_STREAM":          0x1,
+	},
+	"arm": {
+		"AF_ALG":               0x26,
+		"AF_APPLETALK":         0x5,
+		"AF_ASH":               0x12,
+		"AF_ATMPVC":            0x8,
+		"AF_ATMSVC":            0x14,
+		"AF_AX25":              0x3,
+		"AF_BLUETOOTH":         0x1f,
+		"AF_BRIDGE":            0x7,
+		"AF_CAIF":              0x25,
+		"AF_CAN":               0x1d,
+		"AF_ECONET":            0x13,
+		"AF_FILE":              0x1,
+		"AF_IB":                0x1b,
+		"AF_IEEE802154":        0x24,
+		"AF_INET":              0x2,
+		"AF_INET6":             0xa,
+		"AF_IPX":               0x4,
+		"AF_IRDA":              0x17,
+		"AF_ISDN":              0x22,
+		"AF_IUCV":              0x20,
+		"AF_KCM":               0x29,
+		"AF_KEY":               0xf,
+		"AF_LLC":               0x1a,
+		"AF_LOCAL":             0x1,
+		"AF_MPLS":              0x1c,
+		"AF_NETBEUI":           0xd,
+		"AF_NETLINK":           0x10,
+		"AF_NETROM":            0x6,
+		"AF_NFC":               0x27,
+		"AF_PACKET":            0x11,
+		"AF_PHONET":            0x23,
+		"AF_PPPOX":             0x18,
+		"AF_QIPCRTR":           0x2a,
+		"AF_RDS":               0x15,
+		"AF_ROSE":              0xb,
+		"AF_ROUTE":             0x10,
+		"AF_RXRPC":             0x21,
+		"AF_SECURITY":          0xe,
+		"AF_SMC":               0x2b,
+		"AF_SNA":               0x16,
+		"AF_TIPC":              0x1e,
+		"AF_UNIX":              0x1,
+		"AF_UNSPEC":            0x0,
+		"AF_VSOCK":             0x28,
+		"AF_WANPIPE":           0x19,
+		"AF_X25":               0x9,
+		"AF_XDP":               0x2c,
+		"CLONE_CHILD_CLEARTID": 0x200000,
+		"CLONE_CHILD_SETTID":   0x1000000,
+		"CLONE_CLEAR_SIGHAND":  0x100000000,
+		"CLONE_DETACHED":       0x400000,
+		"CLONE_FILES":          0x400,
+		"CLONE_FS":             0x200,
+		"CLONE_INTO_CGROUP":    0x200000000,
+		"CLONE_IO":             0x80000000,
+		"CLONE_NEWCGROUP":      0x2000000,
+		"CLONE_NEWIPC":         0x8000000,
+		"CLONE_NEWNET":         0x40000000,
+		"CLONE_NEWNS":          0x20000,
+		"CLONE_NEWPID":         0x20000000,
+		"CLONE_NEWTIME":        0x80,
+		"CLONE_NEWUSER":        0x10000000,
+		"CLONE_NEWUTS":         0x4000000,
+		"CLONE_PARENT":         0x8000,
+		"CLONE_PARENT_SETTID":  0x100000,
+		"CLONE_PIDFD":          0x1000,
+		"CLONE_PTRACE":         0x2000,
+		"CLONE_SETTLS":         0x80000,
+		"CLONE_SIGHAND":        0x800,
+		"CLONE_SYSVSEM":        0x40000,
+		"CLONE_THREAD":         0x10000,
+		"CLONE_UNTRACED":       0x800000,
+		"CLONE_VFORK":          0x4000,
+		"CLONE_VM":             0x100,
+		"MAP_ANON":             0x20,
+		"MAP_ANONYMOUS":        0x20,
+		"MAP_DENYWRITE":        0x800,
+		"MAP_EXECUTABLE":       0x1000,
+		"MAP_FILE":             0x0,
+		"MAP_FIXED":            0x10,
+		"MAP_FIXED_NOREPLACE":  0x100000,
+		"MAP_GROWSDOWN":        0x100,
+		"MAP_HUGETLB":          0x40000,
+		"MAP_LOCKED":           0x2000,
+		"MAP_NONBLOCK":         0x10000,
+		"MAP_NORESERVE":        0x4000,
+		"MAP_POPULATE":         0x8000,
+		"MAP_PRIVATE":          0x2,
+		"MAP_SHARED":           0x1,
+		"MAP_SHARED_VALIDATE":  0x3,
+		"MAP_STACK":            0x20000,
+		"MAP_SYNC":             0x80000,
+		"O_APPEND":             0x400,
+		"O_ASYNC":              0x2000,
+		"O_CLOEXEC":            0x80000,
+		"O_CREAT":              0x40,
+		"O_DIRECT":             0x10000,
+		"O_DIRECTORY":          0x4000,
+		"O_DSYNC":              0x1000,
+		"O_EXCL":               0x80,
+		"O_FSYNC":              0x101000,
+		"O_LARGEFILE":          0x20000,
+		"O_NDELAY":             0x800,
+		"O_NOATIME":            0x40000,
+		"O_NOCTTY":             0x100,
+		"O_NOFOLLOW":           0x8000,
+		"O_NONBLOCK":           0x800,
+		"O_PATH":               0x200000,
+		"O_RDONLY":             0x0,
+		"O_RDWR":               0x2,
+		"O_RSYNC":              0x101000,
+		"O_SYNC":               0x101000,
+		"O_TMPFILE":            0x404000,
+		"O_TRUNC":              0x200,
+		"O_WRONLY":             0x1,
+		"PROT_EXEC":            0x4,
+		"PROT_GROWSDOWN":       0x1000000,
+		"PROT_GROWSUP":         0x2000000,
+		"PROT_NONE":            0x0,
+		"PROT_READ":            0x1,
+		"PROT_WRITE":           0x2,
+		"SOCK_CLOEXEC":         0x80000,
+		"SOCK_DCCP":            0x6,
+		"SOCK_DGRAM":           0x2,
+		"SOCK_NONBLOCK":        0x800,
+		"SOCK_PACKET":          0xa,
+		"SOCK_RAW":             0x3,
+		"SOCK_RDM":             0x4,
+		"SOCK_SEQPACKET":       0x5,
+		"SOCK_STREAM":          0x1,
+	},
+	"arm64": {
+		"AF_ALG":               0x26,
+		"AF_APPLETALK":         0x5,
+		"AF_ASH":               0x12,
+		"AF_ATMPVC":            0x8,
+		"AF_ATMSVC":            0x14,
+		"AF_AX25":              0x3,
+		"AF_BLUETOOTH":         0x1f,
+		"AF_BRIDGE":            0x7,
+		"AF_CAIF":              0x25,
+		"AF_CAN":               0x1d,
+		"AF_ECONET":            0x13,
+		"AF_FILE":              0x1,
+		"AF_IB":                0x1b,
+		"AF_IEEE802154":        0x24,
+		"AF_INET":              0x2,
+		"AF_INET6":             0xa,
+		"AF_IPX":               0x4,
+		"AF_IRDA":              0x17,
+		"AF_ISDN":              0x22,
+		"AF_IUCV":              0x20,
+		"AF_KCM":               0x29,
+		"AF_KEY":               0xf,
+		"AF_LLC":               0x1a,
+		"AF_LOCAL":             0x1,
+		"AF_MPLS":              0x1c,
+		"AF_NETBEUI":           0xd,
+		"AF_NETLINK":           0x10,
+		"AF_NETROM":            0x6,
+		"AF_NFC":               0x27,
+		"AF_PACKET":            0x11,
+		"AF_PHONET":            0x23,
+		"AF_PPPOX":             0x18,
+		"AF_QIPCRTR":           0x2a,
+		"AF_RDS":               0x15,
+		"AF_ROSE":              0xb,
+		"AF_ROUTE":             0x10,
+		"AF_RXRPC":             0x21,
+		"AF_SECURITY":          0xe,
+		"AF_SMC":               0x2b,
+		"AF_SNA":               0x16,
+		"AF_TIPC":              0x1e,
+		"AF_UNIX":              0x1,
+		"AF_UNSPEC":            0x0,
+		"AF_VSOCK":             0x28,
+		"AF_WANPIPE":           0x19,
+		"AF_X25":               0x9,
+		"AF_XDP":               0x2c,
+		"CLONE_CHILD_CLEARTID": 0x200000,
+		"CLONE_CHILD_SETTID":   0x1000000,
+		"CLONE_CLEAR_SIGHAND":  0x100000000,
+		"CLONE_DETACHED":       0x400000,
+		"CLONE_FILES":          0x400,
+		"CLONE_FS":             0x200,
+		"CLONE_INTO_CGROUP":    0x200000000,
+		"CLONE_IO":             0x80000000,
+		"CLONE_NEWCGROUP":      0x2000000,
+		"CLONE_NEWIPC":         0x8000000,
+		"CLONE_NEWNET":         0x40000000,
+		"CLONE_NEWNS":          0x20000,
+		"CLONE_NEWPID":         0x20000000,
+		"CLONE_NEWTIME":        0x80,
+		"CLONE_NEWUSER":        0x10000000,
+		"CLONE_NEWUTS":         0x4000000,
+		"CLONE_PARENT":         0x8000,
+		"CLONE_PARENT_SETTID":  0x100000,
+		"CLONE_PIDFD":          0x1000,
+		"CLONE_PTRACE":         0x2000,
+		"CLONE_SETTLS":         0x80000,
+		"CLONE_SIGHAND":        0x800,
+		"CLONE_SYSVSEM":        0x40000,
+		"CLONE_THREAD":         0x10000,
+		"CLONE_UNTRACED":       0x800000,
+		"CLONE_VFORK":          0x4000,
+		"CLONE_VM":             0x100,
+		"MAP_ANON":             0x20,
+		"MAP_ANONYMOUS":        0x20,
+		"MAP_DENYWRITE":        0x800,
+		"MAP_EXECUTABLE":       0x1000,
+		"MAP_FILE":             0x0,
+		"MAP_FIXED":            0x10,
+		"MAP_FIXED_NOREPLACE":  0x100000,
+		"MAP_GROWSDOWN":        0x100,
+		"MAP_HUGETLB":          0x40000,
+		"MAP_LOCKED":           0x2000,
+		"MAP_NONBLOCK":         0x10000,
+		"MAP_NORESERVE":        0x4000,
+		"MAP_POPULATE":         0x8000,
+		"MAP_PRIVATE":          0x2,
+		"MAP_SHARED":           0x1,
+		"MAP_SHARED_VALIDATE":  0x3,
+		"MAP_STACK":            0x20000,
+		"MAP_SYNC":             0x80000,
+		"O_APPEND":             0x400,
+		"O_ASYNC":              0x2000,
+		"O_CLOEXEC":            0x80000,
+		"O_CREAT":              0x40,
+		"O_DIRECT":             0x10000,
+		"O_DIRECTORY":          0x4000,
+		"O_DSYNC":              0x1000,
+		"O_EXCL":               0x80,
+		"O_FSYNC":              0x101000,
+		"O_LARGEFILE":          0x0,
+		"O_NDELAY":             0x800,
+		"O_NOATIME":            0x40000,
+		"O_NOCTTY":             0x100,
+		"O_NOFOLLOW":           0x8000,
+		"O_NONBLOCK":           0x800,
+		"O_PATH":               0x200000,
+		"O_RDONLY":             0x0,
+		"O_RDWR":               0x2,
+		"O_RSYNC":              0x101000,
+		"O_SYNC":               0x101000,
+		"O_TMPFILE":            0x404000,
+		"O_TRUNC":              0x200,
+		"O_WRONLY":             0x1,
+		"PROT_EXEC":            0x4,
+		"PROT_GROWSDOWN":       0x1000000,
+		"PROT_GROWSUP":         0x2000000,
+		"PROT_NONE":            0x0,
+		"PROT_READ":            0x1,
+		"PROT_WRITE":           0x2,
+		"SOCK_CLOEXEC":         0x80000,
+		"SOCK_DCCP":            0x6,
+		"SOCK_DGRAM":           0x2,
+		"SOCK_NONBLOCK":        0x800,
+		"SOCK_PACKET":          0xa,
+		"SOCK_RAW":             0x3,
+		"SOCK_RDM":             0x4,
+		"SOCK_SEQPACKET":       0x5,
+		"SOCK_STREAM":          0x1,
+	},
+	"mips": {
+		"AF_ALG":               0x26,
+		"AF_APPLETALK":         0x5,
+		"AF_ASH":               0x12,
+		"AF_ATMPVC":            0x8,
+		"AF_ATMSVC":            0x14,
+		"AF_AX25":              0x3,
+		"AF_BLUETOOTH":         0x1f,
+		"AF_BRIDGE":            0x7,
+		"AF_CAIF":              0x25,
+		"AF_CAN":               0x1d,
+		"AF_ECONET":            0x13,
+		"AF_FILE":              0x1,
+		"AF_IB":                0x1b,
+		"AF_IEEE802154":        0x24,
+		"AF_INET":              0x2,
+		"AF_INET6":             0xa,
+		"AF_IPX":               0x4,
+		"AF_IRDA":              0x17,
+		"AF_ISDN":              0x22,
+		"AF_IUCV":              0x20,
+		"AF_KCM":               0x29,
+		"AF_KEY":               0xf,
+		"AF_LLC":               0x1a,
+		"AF_LOCAL":             0x1,
+		"AF_MPLS":              0x1c,
+		"AF_NETBEUI":           0xd,
+		"AF_NETLINK":           0x10,
+		"AF_NETROM":            0x6,
+		"AF_NFC":               0x27,
+		"AF_PACKET":            0x11,
+		"AF_PHONET":            0x23,
+		"AF_PPPOX":             0x18,
+		"AF_QIPCRTR":           0x2a,
+		"AF_RDS":               0x15,
+		"AF_ROSE":              0xb,
+		"AF_ROUTE":             0x10,
+		"AF_RXRPC":             0x21,
+		"AF_SECURITY":          0xe,
+		"AF_SMC":               0x2b,
+		"AF_SNA":               0x16,
+		"AF_TIPC":              0x1e,
+		"AF_UNIX":              0x1,
+		"AF_UNSPEC":            0x0,
+		"AF_VSOCK":             0x28,
+		"AF_WANPIPE":           0x19,
+		"AF_X25":               0x9,
+		"AF_XDP":               0x2c,
+		"CLONE_CHILD_CLEARTID": 0x200000,
+		"CLONE_CHILD_SETTID":   0x1000000,
+		"CLONE_CLEAR_SIGHAND":  0x100000000,
+		"CLONE_DETACHED":       0x400000,
+		"CLONE_FILES":          0x400,
+		"CLONE_FS":             0x200,
+		"CLONE_INTO_CGROUP":    0x200000000,
+		"CLONE_IO":             0x80000000,
+		"CLONE_NEWCGROUP":      0x2000000,
+		"CLONE_NEWIPC":         0x8000000,
+		"CLONE_NEWNET":         0x40000000,
+		"CLONE_NEWNS":          0x20000,
+		"CLONE_NEWPID":         0x20000000,
+		"CLONE_NEWTIME":        0x80,
+		"CLONE_NEWUSER":        0x10000000,
+		"CLONE_NEWUTS":         0x4000000,
+		"CLONE_PARENT":         0x8000,
+		"CLONE_PARENT_SETTID":  0x100000,
+		"CLONE_PIDFD":          0x1000,
+		"CLONE_PTRACE":         0x2000,
+		"CLONE_SETTLS":         0x80000,
+		"CLONE_SIGHAND":        0x800,
+		"CLONE_SYSVSEM":        0x40000,
+		"CLONE_THREAD":         0x10000,
+		"CLONE_UNTRACED":       0x800000,
+		"CLONE_VFORK":          0x4000,
+		"CLONE_VM":             0x100,
+		"MAP_ANON":             0x800,
+		"MAP_ANONYMOUS":        0x800,
+		"MAP_DENYWRITE":        0x2000,
+		"MAP_EXECUTABLE":       0x4000,
+		"MAP_FILE":             0x0,
+		"MAP_FIXED":            0x10,
+		"MAP_FIXED_NOREPLACE":  0x100000,
+		"MAP_GROWSDOWN":        0x1000,
+		"MAP_HUGETLB":          0x80000,
+		"MAP_LOCKED":           0x8000,
+		"MAP_NONBLOCK":         0x20000,
+		"MAP_NORESERVE":        0x400,
+		"MAP_POPULATE":         0x10000,
+		"MAP_PRIVATE":          0x2,
+		"MAP_RENAME":           0x800,
+		"MAP_SHARED":           0x1,
+		"MAP_SHARED_VALIDATE":  0x3,
+		"MAP_STACK":            0x40000,
+		"O_APPEND":             0x8,
+		"O_ASYNC":              0x1000,
+		"O_CLOEXEC":            0x80000,
+		"O_CREAT":              0x100,
+		"O_DIRECT":             0x8000,
+		"O_DIRECTORY":          0x10000,
+		"O_DSYNC":              0x10,
+		"O_EXCL":               0x400,
+		"O_FSYNC":              0x4010,
+		"O_LARGEFILE":          0x2000,
+		"O_NDELAY":             0x80,
+		"O_NOATIME":            0x40000,
+		"O_NOCTTY":             0x800,
+		"O_NOFOLLOW":           0x20000,
+		"O_NONBLOCK":           0x80,
+		"O_PATH":               0x200000,
+		"O_RDONLY":             0x0,
+		"O_RDWR":               0x2,
+		"O_RSYNC":              0x4010,
+		"O_SYNC":               0x4010,
+		"O_TMPFILE":            0x410000,
+		"O_TRUNC":              0x200,
+		"O_WRONLY":             0x1,
+		"PROT_EXEC":            0x4,
+		"PROT_GROWSDOWN":       0x1000000,
+		"PROT_GROWSUP":         0x2000000,
+		"PROT_NONE":            0x0,
+		"PROT_READ":            0x1,
+		"PROT_WRITE":           0x2,
+		"SOCK_CLOEXEC":         0x80000,
+		"SOCK_DCCP":            0x6,
+		"SOCK_DGRAM":           0x1,
+		"SOCK_NONBLOCK":        0x80,
+		"SOCK_PACKET":          0xa,
+		"SOCK_RAW":             0x3,
+		"SOCK_RDM":             0x4,
+		"SOCK_SEQPACKET":       0x5,
+		"SOCK_STREAM":          0x2,
+	},
+	"mipsel": {
+		"AF_ALG":               0x26,
+		"AF_APPLETALK":         0x5,
+		"AF_ASH":               0x12,
+		"AF_ATMPVC":            0x8,
+		"AF_ATMSVC":            0x14,
+		"AF_AX25":              0x3,
+		"AF_BLUETOOTH":         0x1f,
+		"AF_BRIDGE":            0x7,
+		"AF_CAIF":              0x25,
+		"AF_CAN":               0x1d,
+		"AF_ECONET":            0x13,
+		"AF_FILE":              0x1,
+		"AF_IB":                0x1b,
+		"AF_IEEE802154":        0x24,
+		"AF_INET":              0x2,
+		"AF_INET6":             0xa,
+		"AF_IPX":               0x4,
+		"AF_IRDA":              0x17,
+		"AF_ISDN":              0x22,
+		"AF_IUCV":              0x20,
+		"AF_KCM":               0x29,
+		"AF_KEY":               0xf,
+		"AF_LLC":               0x1a,
+		"AF_LOCAL":             0x1,
+		"AF_MPLS":              0x1c,
+		"AF_NETBEUI":           0xd,
+		"AF_NETLINK":           0x10,
+		"AF_NETROM":            0x6,
+		"AF_NFC":               0x27,
+		"AF_PACKET":            0x11,
+		"AF_PHONET":            0x23,
+		"AF_PPPOX":             0x18,
+		"AF_QIPCRTR":           0x2a,
+		"AF_RDS":               0x15,
+		"AF_ROSE":              0xb,
+		"AF_ROUTE":             0x10,
+		"AF_RXRPC":             0x21,
+		"AF_SECURITY":          0xe,
+		"AF_SMC":               0x2b,
+		"AF_SNA":               0x16,
+		"AF_TIPC":              0x1e,
+		"AF_UNIX":              0x1,
+		"AF_UNSPEC":            0x0,
+		"AF_VSOCK":             0x28,
+		"AF_WANPIPE":           0x19,
+		"AF_X25":               0x9,
+		"AF_XDP":               0x2c,
+		"CLONE_CHILD_CLEARTID": 0x200000,
+		"CLONE_CHILD_SETTID":   0x1000000,
+		"CLONE_CLEAR_SIGHAND":  0x100000000,
+		"CLONE_DETACHED":       0x400000,
+		"CLONE_FILES":          0x400,
+		"CLONE_FS":             0x200,
+		"CLONE_INTO_CGROUP":    0x200000000,
+		"CLONE_IO":             0x80000000,
+		"CLONE_NEWCGROUP":      0x2000000,
+		"CLONE_NEWIPC":         0x8000000,
+		"CLONE_NEWNET":         0x40000000,
+		"CLONE_NEWNS":          0x20000,
+		"CLONE_NEWPID":         0x20000000,
+		"CLONE_NEWTIME":        0x80,
+		"CLONE_NEWUSER":        0x10000000,
+		"CLONE_NEWUTS":         0x4000000,
+		"CLONE_PARENT":         0x8000,
+		"CLONE_PARENT_SETTID":  0x100000,
+		"CLONE_PIDFD":          0x1000,
+		"CLONE_PTRACE":         0x2000,
+		"CLONE_SETTLS":         0x80000,
+		"CLONE_SIGHAND":        0x800,
+		"CLONE_SYSVSEM":        0x40000,
+		"CLONE_THREAD":         0x10000,
+		"CLONE_UNTRACED":       0x800000,
+		"CLONE_VFORK":          0x4000,
+		"CLONE_VM":             0x100,
+		"MAP_ANON":             0x800,
+		"MAP_ANONYMOUS":        0x800,
+		"MAP_DENYWRITE":        0x2000,
+		"MAP_EXECUTABLE":       0x4000,
+		"MAP_FILE":             0x0,
+		"MAP_FIXED":            0x10,
+		"MAP_FIXED_NOREPLACE":  0x100000,
+		"MAP_GROWSDOWN":        0x1000,
+		"MAP_HUGETLB":          0x80000,
+		"MAP_LOCKED":           0x8000,
+		"MAP_NONBLOCK":         0x20000,
+		"MAP_NORESERVE":        0x400,
+		"MAP_POPULATE":         0x10000,
+		"MAP_PRIVATE":          0x2,
+		"MAP_RENAME":           0x800,
+		"MAP_SHARED":           0x1,
+		"MAP_SHARED_VALIDATE":  0x3,
+		"MAP_STACK":            0x40000,
+		"O_APPEND":             0x8,
+		"O_ASYNC":              0x1000,
+		"O_CLOEXEC":            0x80000,
+		"O_CREAT":              0x100,
+		"O_DIRECT":             0x8000,
+		"O_DIRECTORY":          0x10000,
+		"O_DSYNC":              0x10,
+		"O_EXCL":               0x400,
+		"O_FSYNC":              0x4010,
+		"O_LARGEFILE":          0x2000,
+		"O_NDELAY":             0x80,
+		"O_NOATIME":            0x40000,
+		"O_NOCTTY":             0x800,
+		"O_NOFOLLOW":           0x20000,
+		"O_NONBLOCK":           0x80,
+		"O_PATH":               0x200000,
+		"O_RDONLY":             0x0,
+		"O_RDWR":               0x2,
+		"O_RSYNC":              0x4010,
+		"O_SYNC":               0x4010,
+		"O_TMPFILE":            0x410000,
+		"O_TRUNC":              0x200,
+		"O_WRONLY":             0x1,
+		"PROT_EXEC":            0x4,
+		"PROT_GROWSDOWN":       0x1000000,
+		"PROT_GROWSUP":         0x2000000,
+		"PROT_NONE":            0x0,
+		"PROT_READ":            0x1,
+		"PROT_WRITE":           0x2,
+		"SOCK_CLOEXEC":         0x80000,
+		"SOCK_DCCP":            0x6,
+		"SOCK_DGRAM":           0x1,
+		"SOCK_NONBLOCK":        0x80,
+		"SOCK_PACKET":          0xa,
+		"SOCK_RAW":             0x3,
+		"SOCK_RDM":             0x4,
+		"SOCK_SEQPACKET":       0x5,
+		"SOCK_STREAM":          0x2,
+	},
+	"mips64": {
+		"AF_ALG":               0x26,
+		"AF_APPLETALK":         0x5,
+		"AF_ASH":               0x12,
+		"AF_ATMPVC":            0x8,
+		"AF_ATMSVC":            0x14,
+		"AF_AX25":              0x3,
+		"AF_BLUETOOTH":         0x1f,
+		"AF_BRIDGE":            0x7,
+		"AF_CAIF":              0x25,
+		"AF_CAN":               0x1d,
+		"AF_ECONET":            0x13,
+		"AF_FILE":              0x1,
+		"AF_IB":                0x1b,
+		"AF_IEEE802154":        0x24,
+		"AF_INET":              0x2,
+		"AF_INET6":             0xa,
+		"AF_IPX":               0x4,
+		"AF_IRDA":              0x17,
+		"AF_ISDN":              0x22,
+		"AF_IUCV":              0x20,
+		"AF_KCM":               0x29,
+		"AF_KEY":               0xf,
+		"AF_LLC":               0x1a,
+		"AF_LOCAL":             0x1,
+		"AF_MPLS":              0x1c,
+		"AF_NETBEUI":           0xd,
+		"AF_NETLINK":           0x10,
+		"AF_NETROM":            0x6,
+		"AF_NFC":               0x27,
+		"AF_PACKET":            0x11,
+		"AF_PHONET":            0x23,
+		"AF_PPPOX":             0x18,
+		"AF_QIPCRTR":           0x2a,
+		"AF_RDS":               0x15,
+		"AF_ROSE":              0xb,
+		"AF_ROUTE":             0x10,
+		"AF_RXRPC":             0x21,
+		"AF_SECURITY":          0xe,
+		"AF_SMC":               0x2b,
+		"AF_SNA":               0x16,
+		"AF_TIPC":              0x1e,
+		"AF_UNIX":              0x1,
+		"AF_UNSPEC":            0x0,
+		"AF_VSOCK":             0x28,
+		"AF_WANPIPE":           0x19,
+		"AF_X25":               0x9,
+		"AF_XDP":               0x2c,
+		"CLONE_CHILD_CLEARTID": 0x200000,
+		"CLONE_CHILD_SETTID":   0x1000000,
+		"CLONE_CLEAR_SIGHAND":  0x100000000,
+		"CLONE_DETACHED":       0x400000,
+		"CLONE_FILES":          0x400,
+		"CLONE_FS":             0x200,
+		"CLONE_INTO_CGROUP":    0x200000000,
+		"CLONE_IO":             0x80000000,
+		"CLONE_NEWCGROUP":      0x2000000,
+		"CLONE_NEWIPC":         0x8000000,
+		"CLONE_NEWNET":         0x40000000,
+		"CLONE_NEWNS":          0x20000,
+		"CLONE_NEWPID":         0x20000000,
+		"CLONE_NEWTIME":        0x80,
+		"CLONE_NEWUSER":        0x10000000,
+		"CLONE_NEWUTS":         0x4000000,
+		"CLONE_PARENT":         0x8000,
+		"CLONE_PARENT_SETTID":  0x100000,
+		"CLONE_PIDFD":          0x1000,
+		"CLONE_PTRACE":         0x2000,
+		"CLONE_SETTLS":         0x80000,
+		"CLONE_SIGHAND":        0x800,
+		"CLONE_SYSVSEM":        0x40000,
+		"CLONE_THREAD":         0x10000,
+		"CLONE_UNTRACED":       0x800000,
+		"CLONE_VFORK":          0x4000,
+		"CLONE_VM":             0x100,
+		"MAP_ANON":             0x800,
+		"MAP_ANONYMOUS":        0x800,
+		"MAP_DENYWRITE":        0x2000,
+		"MAP_EXECUTABLE":       0x4000,
+		"MAP_FILE":             0x0,
+		"MAP_FIXED":            0x10,
+		"MAP_FIXED_NOREPLACE":  0x100000,
+		"MAP_GROWSDOWN":        0x1000,
+		"MAP_HUGETLB":          0x80000,
+		"MAP_LOCKED":           0x8000,
+		"MAP_NONBLOCK":         0x20000,
+		"MAP_NORESERVE":        0x400,
+		"MAP_POPULATE":         0x10000,
+		"MAP_PRIVATE":          0x2,
+		"MAP_RENAME":           0x800,
+		"MAP_SHARED":           0x1,
+		"MAP_SHARED_VALIDATE":  0x3,
+		"MAP_STACK":            0x40000,
+		"O_APPEND":             0x8,
+		"O_ASYNC":              0x1000,
+		"O_CLOEXEC":            0x80000,
+		"O_CREAT":              0x100,
+		"O_DIRECT":             0x8000,
+		"O_DIRECTORY":          0x10000,
+		"O_DSYNC":              0x10,
+		"O_EXCL":               0x400,
+		"O_FSYNC":              0x4010,
+		"O_LARGEFILE":          0x0,
+		"O_NDELAY":             0x80,
+		"O_NOATIME":            0x40000,
+		"O_NOCTTY":             0x800,
+		"O_NOFOLLOW":           0x20000,
+		"O_NONBLOCK":           0x80,
+		"O_PATH":               0x200000,
+		"O_RDONLY":             0x0,
+		"O_RDWR":               0x2,
+		"O_RSYNC":              0x4010,
+		"O_SYNC":               0x4010,
+		"O_TMPFILE":            0x410000,
+		"O_TRUNC":              0x200,
+		"O_WRONLY":             0x1,
+		"PROT_EXEC":            0x4,
+		"PROT_GROWSDOWN":       0x1000000,
+		"PROT_GROWSUP":         0x2000000,
+		"PROT_NONE":            0x0,
+		"PROT_READ":            0x1,
+		"PROT_WRITE":           0x2,
+		"SOCK_CLOEXEC":         0x80000,
+		"SOCK_DCCP":            0x6,
+		"SOCK_DGRAM":           0x1,
+		"SOCK_NONBLOCK":        0x80,
+		"SOCK_PACKET":          0xa,
+		"SOCK_RAW":             0x3,
+		"SOCK_RDM":             0x4,
+		"SOCK_SEQPACKET":       0x5,
+		"SOCK_STREAM":          0x2,
+	},
+	"mipsel64": {
+		"AF_ALG":               0x26,
+		"AF_APPLETALK":         0x5,
+		"AF_ASH":               0x12,
+		"AF_ATMPVC":            0x8,
+		"AF_ATMSVC":            0x14,
+		"AF_AX25":              0x3,
+		"AF_BLUETOOTH":         0x1f,
+		"AF_BRIDGE":            0x7,
+		"AF_CAIF":              0x25,
+		"AF_CAN":               0x1d,
+		"AF_ECONET":            0x13,
+		"AF_FILE":              0x1,
+		"AF_IB":                0x1b,
+		"AF_IEEE802154":        0x24,
+		"AF_INET":              0x2,
+		"AF_INET6":             0xa,
+		"AF_IPX":               0x4,
+		"AF_IRDA":              0x17,
+		"AF_ISDN":              0x22,
+		"AF_IUCV":              0x20,
+		"AF_KCM":               0x29,
+		"AF_KEY":               0xf,
+		"AF_LLC":               0x1a,
+		"AF_LOCAL":             0x1,
+		"AF_MPLS":              0x1c,
+		"AF_NETBEUI":           0xd,
+		"AF_NETLINK":           0x10,
+		"AF_NETROM":            0x6,
+		"AF_NFC":               0x27,
+		"AF_PACKET":            0x11,
+		"AF_PHONET":            0x23,
+		"AF_PPPOX":             0x18,
+		"AF_QIPCRTR":           0x2a,
+		"AF_RDS":               0x15,
+		"AF_ROSE":              0xb,
+		"AF_ROUTE":             0x10,
+		"AF_RXRPC":             0x21,
+		"AF_SECURITY":          0xe,
+		"AF_SMC":               0x2b,
+		"AF_SNA":               0x16,
+		"AF_TIPC":              0x1e,
+		"AF_UNIX":              0x1,
+		"AF_UNSPEC":            0x0,
+		"AF_VSOCK":             0x28,
+		"AF_WANPIPE":           0x19,
+		"AF_X25":               0x9,
+		"AF_XDP":               0x2c,
+		"CLONE_CHILD_CLEARTID": 0x200000,
+		"CLONE_CHILD_SETTID":   0x1000000,
+		"CLONE_CLEAR_SIGHAND":  0x100000000,
+		"CLONE_DETACHED":       0x400000,
+		"CLONE_FILES":          0x400,
+		"CLONE_FS":             0x200,
+		"CLONE_INTO_CGROUP":    0x200000000,
+		"CLONE_IO":             0x80000000,
+		"CLONE_NEWCGROUP":      0x2000000,
+		"CLONE_NEWIPC":         0x8000000,
+		"CLONE_NEWNET":         0x40000000,
+		"CLONE_NEWNS":          0x20000,
+		"CLONE_NEWPID":         0x20000000,
+		"CLONE_NEWTIME":        0x80,
+		"CLONE_NEWUSER":        0x10000000,
+		"CLONE_NEWUTS":         0x4000000,
+		"CLONE_PARENT":         0x8000,
+		"CLONE_PARENT_SETTID":  0x100000,
+		"CLONE_PIDFD":          0x1000,
+		"CLONE_PTRACE":         0x2000,
+		"CLONE_SETTLS":         0x80000,
+		"CLONE_SIGHAND":        0x800,
+		"CLONE_SYSVSEM":        0x40000,
+		"CLONE_THREAD":         0x10000,
+		"CLONE_UNTRACED":       0x800000,
+		"CLONE_VFORK":          0x4000,
+		"CLONE_VM":             0x100,
+		"MAP_ANON":             0x800,
+		"MAP_ANONYMOUS":        0x800,
+		"MAP_DENYWRITE":        0x2000,
+		"MAP_EXECUTABLE":       0x4000,
+		"MAP_FILE":             0x0,
+		"MAP_FIXED":            0x10,
+		"MAP_FIXED_NOREPLACE":  0x100000,
+		"MAP_GROWSDOWN":        0x1000,
+		"MAP_HUGETLB":          0x80000,
+		"MAP_LOCKED":           0x8000,
+		"MAP_NONBLOCK":         0x20000,
+		"MAP_NORESERVE":        0x400,
+		"MAP_POPULATE":         0x10000,
+		"MAP_PRIVATE":          0x2,
+		"MAP_RENAME":           0x800,
+		"MAP_SHARED":           0x1,
+		"MAP_SHARED_VALIDATE":  0x3,
+		"MAP_STACK":            0x40000,
+		"O_APPEND":             0x8,
+		"O_ASYNC":              0x1000,
+		"O_CLOEXEC":            0x80000,
+		"O_CREAT":              0x100,
+		"O_DIRECT":             0x8000,
+		"O_DIRECTORY":          0x10000,
+		"O_DSYNC":              0x10,
+		"O_EXCL":               0x400,
+		"O_FSYNC":              0x4010,
+		"O_LARGEFILE":          0x0,
+		"O_NDELAY":             0x80,
+		"O_NOATIME":            0x40000,
+		"O_NOCTTY":             0x800,
+		"O_NOFOLLOW":           0x20000,
+		"O_NONBLOCK":           0x80,
+		"O_PATH":               0x200000,
+		"O_RDONLY":             0x0,
+		"O_RDWR":               0x2,
+		"O_RSYNC":              0x4010,
+		"O_SYNC":               0x4010,
+		"O_TMPFILE":            0x410000,
+		"O_TRUNC":              0x200,
+		"O_WRONLY":             0x1,
+		"PROT_EXEC":            0x4,
+		"PROT_GROWSDOWN":       0x1000000,
+		"PROT_GROWSUP":         0x2000000,
+		"PROT_NONE":            0x0,
+		"PROT_READ":            0x1,
+		"PROT_WRITE":           0x2,
+		"SOCK_CLOEXEC":         0x80000,
+		"SOCK_DCCP":            0x6,
+		"SOCK_DGRAM":           0x1,
+		"SOCK_NONBLOCK":        0x80,
+		"SOCK_PACKET":          0xa,
+		"SOCK_RAW":             0x3,
+		"SOCK_RDM":             0x4,
+		"SOCK_SEQPACKET":       0x5,
+		"SOCK_STREAM":          0x2,
+	},
+	"ppc64le": {
+		"AF_ALG":               0x26,
+		"AF_APPLETALK":         0x5,
+		"AF_ASH":               0x12,
+		"AF_ATMPVC":            0x8,
+		"AF_ATMSVC":            0x14,
+		"AF_AX25":              0x3,
+		"AF_BLUETOOTH":         0x1f,
+		"AF_BRIDGE":            0x7,
+		"AF_CAIF":              0x25,
+		"AF_CAN":               0x1d,
+		"AF_ECONET":            0x13,
+		"AF_FILE":              0x1,
+		"AF_IB":                0x1b,
+		"AF_IEEE802154":        0x24,
+		"AF_INET":              0x2,
+		"AF_INET6":             0xa,
+		"AF_IPX":               0x4,
+		"AF_IRDA":              0x17,
+		"AF_ISDN":              0x22,
+		"AF_IUCV":              0x20,
+		"AF_KCM":               0x29,
+		"AF_KEY":               0xf,
+		"AF_LLC":               0x1a,
+		"AF_LOCAL":             0x1,
+		"AF_MPLS":              0x1c,
+		"AF_NETBEUI":           0xd,
+		"AF_NETLINK":           0x10,
+		"AF_NETROM":            0x6,
+		"AF_NFC":               0x27,
+		"AF_PACKET":            0x11,
+		"AF_PHONET":            0x23,
+		"AF_PPPOX":             0x18,
+		"AF_QIPCRTR":           0x2a,
+		"AF_RDS":               0x15,
+		"AF_ROSE":              0xb,
+		"AF_ROUTE":             0x10,
+		"AF_RXRPC":             0x21,
+		"AF_SECURITY":          0xe,
+		"AF_SMC":               0x2b,
+		"AF_SNA":               0x16,
+		"AF_TIPC":              0x1e,
+		"AF_UNIX":              0x1,
+		"AF_UNSPEC":            0x0,
+		"AF_VSOCK":             0x28,
+		"AF_WANPIPE":           0x19,
+		"AF_X25":               0x9,
+		"AF_XDP":               0x2c,
+		"CLONE_CHILD_CLEARTID": 0x200000,
+		"CLONE_CHILD_SETTID":   0x1000000,
+		"CLONE_CLEAR_SIGHAND":  0x100000000,
+		"CLONE_DETACHED":       0x400000,
+		"CLONE_FILES":          0x400,
+		"CLONE_FS":             0x200,
+		"CLONE_INTO_CGROUP":    0x200000000,
+		"CLONE_IO":             0x80000000,
+		"CLONE_NEWCGROUP":      0x2000000,
+		"CLONE_NEWIPC":         0x8000000,
+		"CLONE_NEWNET":         0x40000000,
+		"CLONE_NEWNS":          0x20000,
+		"CLONE_NEWPID":         0x20000000,
+		"CLONE_NEWTIME":        0x80,
+		"CLONE_NEWUSER":        0x10000000,
+		"CLONE_NEWUTS":         0x4000000,
+		"CLONE_PARENT":         0x8000,
+		"CLONE_PARENT_SETTID":  0x100000,
+		"CLONE_PIDFD":          0x1000,
+		"CLONE_PTRACE":         0x2000,
+		"CLONE_SETTLS":         0x80000,
+		"CLONE_SIGHAND":        0x800,
+		"CLONE_SYSVSEM":        0x40000,
+		"CLONE_THREAD":         0x10000,
+		"CLONE_UNTRACED":       0x800000,
+		"CLONE_VFORK":          0x4000,
+		"CLONE_VM":             0x100,
+		"MAP_ANON":             0x20,
+		"MAP_ANONYMOUS":        0x20,
+		"MAP_DENYWRITE":        0x800,
+		"MAP_EXECUTABLE":       0x1000,
+		"MAP_FILE":             0x0,
+		"MAP_FIXED":            0x10,
+		"MAP_FIXED_NOREPLACE":  0x100000,
+		"MAP_GROWSDOWN":        0x100,
+		"MAP_HUGETLB":          0x40000,
+		"MAP_LOCKED":           0x80,
+		"MAP_NONBLOCK":         0x10000,
+		"MAP_NORESERVE":        0x40,
+		"MAP_POPULATE":         0x8000,
+		"MAP_PRIVATE":          0x2,
+		"MAP_SHARED":           0x1,
+		"MAP_SHARED_VALIDATE":  0x3,
+		"MAP_STACK":            0x20000,
+		"O_APPEND":             0x400,
+		"O_ASYNC":              0x2000,
+		"O_CLOEXEC":            0x80000,
+		"O_CREAT":              0x40,
+		"O_DIRECT":             0x20000,
+		"O_DIRECTORY":          0x4000,
+		"O_DSYNC":              0x1000,
+		"O_EXCL":               0x80,
+		"O_FSYNC":              0x101000,
+		"O_LARGEFILE":          0x0,
+		"O_NDELAY":             0x800,
+		"O_NOATIME":            0x40000,
+		"O_NOCTTY":             0x100,
+		"O_NOFOLLOW":           0x8000,
+		"O_NONBLOCK":           0x800,
+		"O_PATH":               0x200000,
+		"O_RDONLY":             0x0,
+		"O_RDWR":               0x2,
+		"O_RSYNC":              0x101000,
+		"O_SYNC":               0x101000,
+		"O_TMPFILE":            0x404000,
+		"O_TRUNC":              0x200,
+		"O_WRONLY":             0x1,
+		"PROT_EXEC":            0x4,
+		"PROT_GROWSDOWN":       0x1000000,
+		"PROT_GROWSUP":         0x2000000,
+		"PROT_NONE":            0x0,
+		"PROT_READ":            0x1,
+		"PROT_SAO":             0x10,
+		"PROT_WRITE":           0x2,
+		"SOCK_CLOEXEC":         0x80000,
+		"SOCK_DCCP":            0x6,
+		"SOCK_DGRAM":           0x2,
+		"SOCK_NONBLOCK":        0x800,
+		"SOCK_PACKET":          0xa,
+		"SOCK_RAW":             0x3,
+		"SOCK_RDM":             0x4,
+		"SOCK_SEQPACKET":       0x5,
+		"SOCK_STREAM":          0x1,
+	},
+	"s390x": {
+		"AF_ALG":               0x26,
+		"AF_APPLETALK":         0x5,
+		"AF_ASH":               0x12,
+		"AF_ATMPVC":            0x8,
+		"AF_ATMSVC":            0x14,
+		"AF_AX25":              0x3,
+		"AF_BLUETOOTH":         0x1f,
+		"AF_BRIDGE":            0x7,
+		"AF_CAIF":              0x25,
+		"AF_CAN":               0x1d,
+		"AF_ECONET":            0x13,
+		"AF_FILE":              0x1,
+		"AF_IB":                0x1b,
+		"AF_IEEE802154":        0x24,
+		"AF_INET":              0x2,
+		"AF_INET6":             0xa,
+		"AF_IPX":               0x4,
+		"AF_IRDA":              0x17,
+		"AF_ISDN":              0x22,
+		"AF_IUCV":              0x20,
+		"AF_KCM":               0x29,
+		"AF_KEY":               0xf,
+		"AF_LLC":               0x1a,
+		"AF_LOCAL":             0x1,
+		"AF_MPLS":              0x1c,
+		"AF_NETBEUI":           0xd,
+		"AF_NETLINK":           0x10,
+		"AF_NETROM":            0x6,
+		"AF_NFC":               0x27,
+		"AF_PACKET":            0x11,
+		"AF_PHONET":            0x23,
+		"AF_PPPOX":             0x18,
+		"AF_QIPCRTR":           0x2a,
+		"AF_RDS":               0x15,
+		"AF_ROSE":              0xb,
+		"AF_ROUTE":             0x10,
+		"AF_RXRPC":             0x21,
+		"AF_SECURITY":          0xe,
+		"AF_SMC":               0x2b,
+		"AF_SNA":               0x16,
+		"AF_TIPC":              0x1e,
+		"AF_UNIX":              0x1,
+		"AF_UNSPEC":            0x0,
+		"AF_VSOCK":             0x28,
+		"AF_WANPIPE":           0x19,
+		"AF_X25":               0x9,
+		"AF_XDP":               0x2c,
+		"CLONE_CHILD_CLEARTID": 0x200000,
+		"CLONE_CHILD_SETTID":   0x1000000,
+		"CLONE_CLEAR_SIGHAND":  0x100000000,
+		"CLONE_DETACHED":       0x400000,
+		"CLONE_FILES":          0x400,
+		"CLONE_FS":             0x200,
+		"CLONE_INTO_CGROUP":    0x200000000,
+		"CLONE_IO":             0x80000000,
+		"CLONE_NEWCGROUP":      0x2000000,
+		"CLONE_NEWIPC":         0x8000000,
+		"CLONE_NEWNET":         0x40000000,
+		"CLONE_NEWNS":          0x20000,
+		"CLONE_NEWPID":         0x20000000,
+		"CLONE_NEWTIME":        0x80,
+		"CLONE_NEWUSER":        0x10000000,
+		"CLONE_NEWUTS":         0x4000000,
+		"CLONE_PARENT":         0x8000,
+		"CLONE_PARENT_SETTID":  0x100000,
+		"CLONE_PIDFD":          0x1000,
+		"CLONE_PTRACE":         0x2000,
+		"CLONE_SETTLS":         0x80000,
+		"CLONE_SIGHAND":        0x800,
+		"CLONE_SYSVSEM":        0x40000,
+		"CLONE_THREAD":         0x10000,
+		"CLONE_UNTRACED":       0x800000,
+		"CLONE_VFORK":          0x4000,
+		"CLONE_VM":             0x100,
+		"MAP_ANON":             0x20,
+		"MAP_ANONYMOUS":        0x20,
+		"MAP_DENYWRITE":        0x800,
+		"MAP_EXECUTABLE":       0x1000,
+		"MAP_FILE":             0x0,
+		"MAP_FIXED":            0x10,
+		"MAP_FIXED_NOREPLACE":  0x100000,
+		"MAP_GROWSDOWN":        0x100,
+		"MAP_HUGETLB":          0x40000,
+		"MAP_LOCKED":           0x2000,
+		"MAP_NONBLOCK":         0x10000,
+		"MAP_NORESERVE":        0x4000,
+		"MAP_POPULATE":         0x8000,
+		"MAP_PRIVATE":          0x2,
+		"MAP_SHARED":           0x1,
+		"MAP_SHARED_VALIDATE":  0x3,
+		"MAP_STACK":            0x20000,
+		"MAP_SYNC":             0x80000,
+		"O_APPEND":             0x400,
+		"O_ASYNC":              0x2000,
+		"O_CLOEXEC":            0x80000,
+		"O_CREAT":              0x40,
+		"O_DIRECT":             0x4000,
+		"O_DIRECTORY":          0x10000,
+		"O_DSYNC":              0x1000,
+		"O_EXCL":               0x80,
+		"O_FSYNC":              0x101000,
+		"O_LARGEFILE":          0x0,
+		"O_NDELAY":             0x800,
+		"O_NOATIME":            0x40000,
+		"O_NOCTTY":             0x100,
+		"O_NOFOLLOW":           0x20000,
+		"O_NONBLOCK":           0x800,
+		"O_PATH":               0x200000,
+		"O_RDONLY":             0x0,
+		"O_RDWR":               0x2,
+		"O_RSYNC":              0x101000,
+		"O_SYNC":               0x101000,
+		"O_TMPFILE":            0x410000,
+		"O_TRUNC":              0x200,
+		"O_WRONLY":             0x1,
+		"PROT_EXEC":            0x4,
+		"PROT_GROWSDOWN":       0x1000000,
+		"PROT_GROWSUP":         0x2000000,
+		"PROT_NONE":            0x0,
+		"PROT_READ":            0x1,
+		"PROT_WRITE":           0x2,
+		"SOCK_CLOEXEC":         0x80000,
+		"SOCK_DCCP":            0x6,
+		"SOCK_DGRAM":           0x2,
+		"SOCK_NONBLOCK":        0x800,
+		"SOCK_PACKET":          0xa,
+		"SOCK_RAW":             0x3,
+		"SOCK_RDM":             0x4,
+		"SOCK_SEQPACKET":       0x5,
+		"SOCK_STREAM":          0x1,
+	},
+	"riscv64": {
+		"AF_ALG":               0x26,
+		"AF_APPLETALK":         0x5,
+		"AF_ASH":               0x12,
+		"AF_ATMPVC":            0x8,
+		"AF_ATMSVC":            0x14,
+		"AF_AX25":              0x3,
+		"AF_BLUETOOTH":         0x1f,
+		"AF_BRIDGE":            0x7,
+		"AF_CAIF":              0x25,
+		"AF_CAN":               0x1d,
+		"AF_ECONET":            0x13,
+		"AF_FILE":              0x1,
+		"AF_IB":                0x1b,
+		"AF_IEEE802154":        0x24,
+		"AF_INET":              0x2,
+		"AF_INET6":             0xa,
+		"AF_IPX":               0x4,
+		"AF_IRDA":              0x17,
+		"AF_ISDN":              0x22,
+		"AF_IUCV":              0x20,
+		"AF_KCM":               0x29,
+		"AF_KEY":               0xf,
+		"AF_LLC":               0x1a,
+		"AF_LOCAL":             0x1,
+		"AF_MPLS":              0x1c,
+		"AF_NETBEUI":           0xd,
+		"AF_NETLINK":           0x10,
+		"AF_NETROM":            0x6,
+		"AF_NFC":               0x27,
+		"AF_PACKET":            0x11,
+		"AF_PHONET":            0x23,
+		"AF_PPPOX":             0x18,
+		"AF_QIPCRTR":           0x2a,
+		"AF_RDS":               0x15,
+		"AF_ROSE":              0xb,
+		"AF_ROUTE":             0x10,
+		"AF_RXRPC":             0x21,
+		"AF_SECURITY":          0xe,
+		"AF_SMC":               0x2b,
+		"AF_SNA":               0x16,
+		"AF_TIPC":              0x1e,
+		"AF_UNIX":              0x1,
+		"AF_UNSPEC":            0x0,
+		"AF_VSOCK":             0x28,
+		"AF_WANPIPE":           0x19,
+		"AF_X25":               0x9,
+		"AF_XDP":               0x2c,
+		"CLONE_CHILD_CLEARTID": 0x200000,
+		"CLONE_CHILD_SETTID":   0x1000000,
+		"CLONE_CLEAR_SIGHAND":  0x100000000,
+		"CLONE_DETACHED":       0x400000,
+		"CLONE_FILES":          0x400,
+		"CLONE_FS":             0x200,
+		"CLONE_INTO_CGROUP":    0x200000000,
+		"CLONE_IO":             0x80000000,
+		"CLONE_NEWCGROUP":      0x2000000,
+		"CLONE_NEWIPC":         0x8000000,
+		"CLONE_NEWNET":         0x40000000,
+		"CLONE_NEWNS":          0x20000,
+		"CLONE_NEWPID":         0x20000000,
+		"CLONE_NEWTIME":        0x80,
+		"CLONE_NEWUSER":        0x10000000,
+		"CLONE_NEWUTS":         0x4000000,
+		"CLONE_PARENT":         0x8000,
+		"CLONE_PARENT_SETTID":  0x100000,
+		"CLONE_PIDFD":          0x1000,
+		"CLONE_PTRACE":         0x2000,
+		"CLONE_SETTLS":         0x80000,
+		"CLONE_SIGHAND":        0x800,
+		"CLONE_SYSVSEM":        0x40000,
+		"CLONE_THREAD":         0x10000,
+		"CLONE_UNTRACED":       0x800000,
+		"CLONE_VFORK":          0x4000,
+		"CLONE_VM":             0x100,
+		"MAP_ANON":             0x20,
+		"MAP_ANONYMOUS":        0x20,
+		"MAP_DENYWRITE":        0x800,
+		"MAP_EXECUTABLE":       0x1000,
+		"MAP_FILE":             0x0,
+		"MAP_FIXED":            0x10,
+		"MAP_FIXED_NOREPLACE":  0x100000,
+		"MAP_GROWSDOWN":        0x100,
+		"MAP_HUGETLB":          0x40000,
+		"MAP_LOCKED":           0x2000,
+		"MAP_NONBLOCK":         0x10000,
+		"MAP_NORESERVE":        0x4000,
+		"MAP_POPULATE":         0x8000,
+		"MAP_PRIVATE":          0x2,
+		"MAP_SHARED":           0x1,
+		"MAP_SHARED_VALIDATE":  0x3,
+		"MAP_STACK":            0x20000,
+		"MAP_SYNC":             0x80000,
+		"O_APPEND":             0x400,
+		"O_ASYNC":              0x2000,
+		"O_CLOEXEC":            0x80000,
+		"O_CREAT":              0x40,
+		"O_DIRECT":             0x4000,
+		"O_DIRECTORY":          0x10000,
+		"O_DSYNC":              0x1000,
+		"O_EXCL":               0x80,
+		"O_FSYNC":              0x101000,
+		"O_LARGEFILE":          0x0,
+		"O_NDELAY":             0x800,
+		"O_NOATIME":            0x40000,
+		"O_NOCTTY":             0x100,
+		"O_NOFOLLOW":           0x20000,
+		"O_NONBLOCK":           0x800,
+		"O_PATH":               0x200000,
+		"O_RDONLY":             0x0,
+		"O_RDWR":               0x2,
+		"O_RSYNC":              0x101000,
+		"O_SYNC":               0x101000,
+		"O_TMPFILE":            0x410000,
+		"O_TRUNC":              0x200,
+		"O_WRONLY":             0x1,
+		"PROT_EXEC":            0x4,
+		"PROT_GROWSDOWN":       0x1000000,
+		"PROT_GROWSUP":         0x2000000,
+		"PROT_NONE":            0x0,
+		"PROT_READ":            0x1,
+		"PROT_WRITE":           0x2,
+		"SOCK_CLOEXEC":         0x80000,
+		"SOCK_DCCP":            0x6,
+		"SOCK_DGRAM":           0x2,
+		"SOCK_NONBLOCK":        0x800,
+		"SOCK_PACKET":          0xa,
+		"SOCK_RAW":             0x3,
+		"SOCK_RDM":             0x4,
+		"SOCK_SEQPACKET":       0x5,
+		"SOCK_STREAM":          0x1,
+	},
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+
+	"github.com/minio/cli"
+	"github.com/minio/minio-go/v6"
+)
+
+// Serve lambdas.
+var serveCmd = cli.Command{
+	Name:   "serve",
+	Usage:  "Dispatch bucket notifications to lambdas",
+	Action: mainServe,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "profile",
+			Usage: "Seccomp profile to confine lambdas without a profile in their manifest.",
+		},
+		cli.StringFlag{
+			Name:  "journal",
+			Usage: "Directory to record every notification received into, for 'minl replay'.",
+		},
+		cli.Uint64Flag{
+			Name:  "journal-max-size",
+			Usage: "Size in MiB after which journal files are rotated.",
+			Value: 64,
+		},
+		cli.BoolFlag{
+			Name:  "journal-compress",
+			Usage: "Compress rotated journal files with gzip.",
+		},
+		cli.IntFlag{
+			Name:  "max-retries",
+			Usage: "Times a notification is delivered again after the lambda died on it, before it is journaled as failed.",
+			Value: defaultMaxRetries,
+		},
+		cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "Time a lambda has to handle a notification before it is killed, 0 waits forever.",
+			Value: defaultDeliveryTimeout,
+		},
+		cli.BoolFlag{
+			Name:  "strict",
+			Usage: "Fail to start lambdas whose seccomp profile has unknown syscalls, instead of skipping their rules.",
+		},
+	},
+	CustomHelpTemplate: `NAME:
+   minl {{.Name}} - {{.Usage}}
+
+USAGE:
+   minl {{.Name}} [FLAGS] LAMBDA-DIR [LAMBDA-DIR...]
+
+FLAGS:
+  {{range .Flags}}{{.}}
+  {{end}}
+A notification the lambda keeps dying on is given up after --max-retries
+deliveries, so that it does not hold up the ones queued after it. It is
+recorded as failed in the journal, for 'minl replay --failed'. A lambda
+not responding within --timeout is killed and counts as dying on it.
+
+Lambdas are stopped on SIGINT or SIGTERM, a second one exits right away.
+
+ENVIRONMENT VARIABLES:
+   S3_ENDPOINT, ACCESS_KEY, SECRET_KEY, S3_SECURE, S3_REGION
+
+`,
+}
+
+// checkServeSyntax - validate all the passed arguments
+func checkServeSyntax(ctx *cli.Context) {
+	if !ctx.Args().Present() {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+	if ctx.Int("max-retries") < 0 {
+		fmt.Println("Maximum retries must not be negative.")
+		os.Exit(1)
+	}
+	if ctx.Duration("timeout") < 0 {
+		fmt.Println("Timeout must not be negative.")
+		os.Exit(1)
+	}
+}
+
+// dispatcher owns the bucket notification subscriptions of all the
+// registered lambdas and fans notifications out to their handlers.
+type dispatcher struct {
+	client   *minio.Client
+	journal  *journal // nil when not recording.
+	lambdas  []*lambdaHandler
+	handlers map[string][]*lambdaHandler // by bucket.
+}
+
+func newDispatcher(client *minio.Client) *dispatcher {
+	return &dispatcher{
+		client:   client,
+		handlers: make(map[string][]*lambdaHandler),
+	}
+}
+
+// register adds a lambda to be dispatched to, for every bucket it
+// has triggers on.
+func (d *dispatcher) register(h *lambdaHandler) {
+	h.journal = d.journal
+	d.lambdas = append(d.lambdas, h)
+	seen := make(map[string]bool)
+	for _, trigger := range h.lmeta.Triggers {
+		if !seen[trigger.Bucket] {
+			seen[trigger.Bucket] = true
+			d.handlers[trigger.Bucket] = append(d.handlers[trigger.Bucket], h)
+		}
+	}
+}
+
+// bucketEvents returns all the event types lambdas on a bucket
+// listen on, a single subscription per bucket covers all of them.
+func (d *dispatcher) bucketEvents(bucket string) []string {
+	seen := make(map[string]bool)
+	var events []string
+	for _, h := range d.handlers[bucket] {
+		for _, trigger := range h.lmeta.Triggers {
+			if trigger.Bucket != bucket {
+				continue
+			}
+			for _, event := range trigger.Events {
+				if !seen[event] {
+					seen[event] = true
+					events = append(events, event)
+				}
+			}
+		}
+	}
+	sort.Strings(events)
+	return events
+}
+
+// fanOut queues every event with each handler it matches.
+func (d *dispatcher) fanOut(bucket string, notificationInfo NotificationInfo, doneCh <-chan struct{}) {
+	for _, event := range notificationInfo.Records {
+		for _, h := range d.handlers[bucket] {
+			if !matchNotification(h.lmeta, event) {
+				continue
+			}
+			select {
+			case h.queueCh <- NotificationInfo{Records: []NotificationEvent{event}}:
+			case <-doneCh:
+				return
+			}
+		}
+	}
+}
+
+// run dispatches notifications until doneCh is closed.
+func (d *dispatcher) run(doneCh <-chan struct{}) {
+	errCh := make(chan error, len(d.handlers))
+	errDoneCh := make(chan struct{})
+	go func() {
+		defer close(errDoneCh)
+		for err := range errCh {
+			fmt.Println("Unable to listen bucket notification, retrying.", err)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for _, h := range d.lambdas {
+		wg.Add(1)
+		go func(h *lambdaHandler) {
+			defer wg.Done()
+			h.run(doneCh)
+		}(h)
+	}
+	for bucket := range d.handlers {
+		wg.Add(1)
+		go func(bucket string) {
+			defer wg.Done()
+			// Filtering by prefix and suffix is done per lambda.
+			for notificationInfo := range listenBucketNotification(d.client, bucket, "", "", d.bucketEvents(bucket), doneCh, errCh) {
+				if d.journal != nil {
+					if err := d.journal.record(bucket, notificationInfo); err != nil {
+						fmt.Println("Unable to record notification.", err)
+					}
+				}
+				d.fanOut(bucket, notificationInfo, doneCh)
+			}
+		}(bucket)
+	}
+	wg.Wait()
+
+	// Listeners are all gone, report their last errors.
+	close(errCh)
+	<-errDoneCh
+}
+
+func mainServe(ctx *cli.Context) {
+	checkServeSyntax(ctx)
+
+	profile := ctx.String("profile")
+	if profile != "" {
+		if _, err := loadSeccompProfile(profile); err != nil {
+			fmt.Println("Unable to load seccomp profile.", err)
+			os.Exit(1)
+		}
+	}
+
+	client, err := newS3Client(newS3ConfigFromEnv())
+	if err != nil {
+		fmt.Println("Unable to initialize minio client.", err)
+		os.Exit(1)
+	}
+	d := newDispatcher(client)
+	if dir := ctx.String("journal"); dir != "" {
+		j, err := openJournal(dir, int64(ctx.Uint64("journal-max-size"))<<20, ctx.Bool("journal-compress"))
+		if err != nil {
+			fmt.Println("Unable to open journal.", err)
+			os.Exit(1)
+		}
+		defer j.Close()
+		d.journal = j
+	}
+	strict := ctx.Bool("strict")
+	for _, lambdaDir := range ctx.Args() {
+		m, err := loadManifest(lambdaDir)
+		if err != nil {
+			fmt.Println("Unable to load lambda.", err)
+			os.Exit(1)
+		}
+		lmeta := newLambdaMetaFromManifest(m)
+		lambdaProfile := lambdaProfile(lambdaDir, lmeta)
+		if lambdaProfile == "" {
+			lambdaProfile = profile
+		} else if _, err = loadSeccompProfile(lambdaProfile); err != nil {
+			fmt.Println("Unable to load seccomp profile.", err)
+			os.Exit(1)
+		}
+		args, err := lambdaCommand(lambdaDir, lmeta)
+		if err != nil {
+			fmt.Println("Unable to prepare lambda.", err)
+			os.Exit(1)
+		}
+		lambdaDir := lambdaDir
+		h := newLambdaHandler(lmeta, func() (*exec.Cmd, error) {
+			return sandboxCommand(lambdaDir, lambdaProfile, strict, lmeta, args)
+		})
+		h.maxRetries = ctx.Int("max-retries")
+		h.timeout = ctx.Duration("timeout")
+		d.register(h)
+	}
+
+	doneCh := make(chan struct{})
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		fmt.Println("Stopping lambdas, interrupt again to exit right away.")
+		close(doneCh)
+		<-sigCh
+		os.Exit(1)
+	}()
+
+	d.run(doneCh)
+}
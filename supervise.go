@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/minio/cli"
+	"github.com/minio/minl/seccomp/seccomp"
+)
+
+// Internal command started by 'minl sandbox' for profiles with
+// SCMP_ACT_NOTIFY rules, it receives the seccomp listener of the lambda
+// on fd 3 and decides its notified syscalls until the lambda exits.
+var superviseCmd = cli.Command{
+	Name:   "supervise",
+	Usage:  "Decide notified syscalls of a sandboxed command",
+	Action: mainSupervise,
+	Hidden: true,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "profile",
+			Usage: "Seccomp profile the command is confined with.",
+		},
+	},
+}
+
+func mainSupervise(ctx *cli.Context) {
+	profile := ctx.String("profile")
+	if profile == "" {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1)
+	}
+	scomp, err := loadSeccompProfile(profile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to load seccomp profile.", err)
+		os.Exit(1)
+	}
+
+	listener, err := receiveListener(os.NewFile(3, "supervisor"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to receive seccomp listener.", err)
+		os.Exit(1)
+	}
+	supervisor, err := seccomp.NewSupervisor(listener, scomp)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to supervise seccomp profile.", err)
+		os.Exit(1)
+	}
+	supervisor.Denied = func(pid int, syscall, target string) {
+		fmt.Fprintf(os.Stderr, "Denied %s %s of process %d by %s.\n", syscall, target, pid, profile)
+	}
+	if err = supervisor.Serve(); err != nil {
+		fmt.Fprintln(os.Stderr, "Unable to supervise seccomp profile.", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,77 @@
+// +build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// PR_SET_PTRACER of Yama, missing from package syscall.
+const prSetPtracer = 0x59616d61
+
+// startSupervisor starts 'minl supervise' for profile, returning the
+// socket to send it the listener with. It has to start before any
+// filter is loaded, otherwise its own syscalls would wait for itself.
+func startSupervisor(profile string) (*os.File, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, err
+	}
+	conn := os.NewFile(uintptr(fds[0]), "supervisor")
+	peer := os.NewFile(uintptr(fds[1]), "supervisor")
+	defer peer.Close()
+
+	cmd := exec.Command(self, superviseCmd.Name, "--profile", profile)
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = []*os.File{peer}
+	if err = cmd.Start(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	// Yama only lets ancestors read the memory of processes, the
+	// supervisor is a child of the lambda. Descendants of the lambda stay
+	// out of reach and their notified syscalls are denied, unless Yama is
+	// disabled or the supervisor has CAP_SYS_PTRACE.
+	if _, _, e1 := syscall.RawSyscall(syscall.SYS_PRCTL, prSetPtracer, uintptr(cmd.Process.Pid), 0); e1 != 0 && e1 != syscall.EINVAL {
+		conn.Close()
+		cmd.Process.Kill()
+		return nil, e1
+	}
+	return conn, nil
+}
+
+// sendListener passes the listener to the supervisor over conn.
+func sendListener(conn, listener *os.File) error {
+	rights := syscall.UnixRights(int(listener.Fd()))
+	return syscall.Sendmsg(int(conn.Fd()), []byte{0}, rights, nil, 0)
+}
+
+// receiveListener reads the listener sent by sendListener from conn.
+func receiveListener(conn *os.File) (*os.File, error) {
+	defer conn.Close()
+	buf := make([]byte, 1)
+	oob := make([]byte, syscall.CmsgSpace(4))
+	_, oobn, _, _, err := syscall.Recvmsg(int(conn.Fd()), buf, oob, 0)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, err
+	}
+	for _, msg := range msgs {
+		fds, err := syscall.ParseUnixRights(&msg)
+		if err == nil && len(fds) == 1 {
+			syscall.CloseOnExec(fds[0])
+			return os.NewFile(uintptr(fds[0]), "seccomp-listener"), nil
+		}
+	}
+	return nil, fmt.Errorf("no listener received")
+}
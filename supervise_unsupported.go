@@ -0,0 +1,25 @@
+// +build !linux
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+var errSuperviseUnsupported = errors.New("seccomp supervisor is not supported on this platform")
+
+// startSupervisor is not supported on this platform.
+func startSupervisor(profile string) (*os.File, error) {
+	return nil, errSuperviseUnsupported
+}
+
+// sendListener is not supported on this platform.
+func sendListener(conn, listener *os.File) error {
+	return errSuperviseUnsupported
+}
+
+// receiveListener is not supported on this platform.
+func receiveListener(conn *os.File) (*os.File, error) {
+	return nil, errSuperviseUnsupported
+}
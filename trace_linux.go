@@ -0,0 +1,165 @@
+// +build linux
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// ptrace requests and options missing from package syscall.
+const (
+	ptraceGetSyscallInfo = 0x420e
+	ptraceOExitKill      = 0x100000
+)
+
+// Operation of a ptraceSyscallInfo.
+const ptraceSyscallInfoEntry = 1
+
+// ptraceSyscallInfo is laid out like the kernel's struct
+// ptrace_syscall_info, reporting syscalls alike on all architectures.
+type ptraceSyscallInfo struct {
+	Op      uint8
+	_       [3]uint8
+	Arch    uint32
+	IP      uint64
+	SP      uint64
+	Nr      uint64
+	Args    [6]uint64
+	RetData uint32
+	_       uint32
+}
+
+// syscallTracer runs a command under ptrace and calls record for every
+// syscall entered by it and its descendants.
+type syscallTracer struct {
+	record func(audit uint32, nr uint64, args [6]uint64)
+
+	startCh chan error
+	doneCh  chan struct{}
+	failure string
+	err     error
+}
+
+func newSyscallTracer(record func(audit uint32, nr uint64, args [6]uint64)) *syscallTracer {
+	return &syscallTracer{
+		record:  record,
+		startCh: make(chan error, 1),
+		doneCh:  make(chan struct{}),
+	}
+}
+
+// start starts cmd traced, tracing happens on a dedicated thread as
+// ptrace only accepts requests from the thread which attached.
+func (t *syscallTracer) start(cmd *exec.Cmd) error {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Ptrace = true
+	go t.run(cmd)
+	return <-t.startCh
+}
+
+// wait waits until all traced processes exited, it returns how the
+// command failed, empty if it exited successfully.
+func (t *syscallTracer) wait() (string, error) {
+	<-t.doneCh
+	return t.failure, t.err
+}
+
+func (t *syscallTracer) run(cmd *exec.Cmd) {
+	defer close(t.doneCh)
+	runtime.LockOSThread()
+
+	if err := cmd.Start(); err != nil {
+		t.startCh <- err
+		return
+	}
+	pid := cmd.Process.Pid
+
+	// The command stops with SIGTRAP once exec'ed.
+	var ws syscall.WaitStatus
+	if _, err := syscall.Wait4(pid, &ws, syscall.WALL, nil); err != nil {
+		t.startCh <- err
+		return
+	}
+	var info ptraceSyscallInfo
+	if err := t.syscallInfo(pid, &info); err != nil {
+		cmd.Process.Kill()
+		syscall.Wait4(pid, &ws, syscall.WALL, nil)
+		t.startCh <- errors.New("tracing syscalls requires Linux 5.3 or later")
+		return
+	}
+	options := syscall.PTRACE_O_TRACESYSGOOD | syscall.PTRACE_O_TRACECLONE | syscall.PTRACE_O_TRACEFORK |
+		syscall.PTRACE_O_TRACEVFORK | syscall.PTRACE_O_TRACEEXEC | ptraceOExitKill
+	if err := syscall.PtraceSetOptions(pid, options); err != nil {
+		cmd.Process.Kill()
+		syscall.Wait4(pid, &ws, syscall.WALL, nil)
+		t.startCh <- err
+		return
+	}
+	syscall.PtraceSyscall(pid, 0)
+	t.startCh <- nil
+
+	// New threads and processes are traced automatically, they start
+	// with a SIGSTOP which must not be delivered.
+	seen := map[int]bool{pid: true}
+	for {
+		wpid, err := syscall.Wait4(-1, &ws, syscall.WALL, nil)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil {
+			if err != syscall.ECHILD {
+				t.err = err
+			}
+			return
+		}
+		if ws.Exited() || ws.Signaled() {
+			if wpid == pid && !(ws.Exited() && ws.ExitStatus() == 0) {
+				t.failure = waitStatusReason(ws)
+			}
+			continue
+		}
+		if !ws.Stopped() {
+			continue
+		}
+
+		sig := ws.StopSignal()
+		switch {
+		case sig == syscall.SIGTRAP|0x80:
+			if err = t.syscallInfo(wpid, &info); err == nil && info.Op == ptraceSyscallInfoEntry {
+				t.record(info.Arch, info.Nr, info.Args)
+			}
+			sig = 0
+		case sig == syscall.SIGTRAP:
+			// Clone, fork, vfork and exec events.
+			sig = 0
+		case sig == syscall.SIGSTOP && !seen[wpid]:
+			sig = 0
+		}
+		seen[wpid] = true
+		syscall.PtraceSyscall(wpid, int(sig))
+	}
+}
+
+func (t *syscallTracer) syscallInfo(pid int, info *ptraceSyscallInfo) error {
+	_, _, e1 := syscall.RawSyscall6(syscall.SYS_PTRACE, ptraceGetSyscallInfo, uintptr(pid),
+		unsafe.Sizeof(*info), uintptr(unsafe.Pointer(info)), 0, 0)
+	if e1 != 0 {
+		return e1
+	}
+	return nil
+}
+
+// waitStatusReason describes how a traced process finished.
+func waitStatusReason(ws syscall.WaitStatus) string {
+	if ws.Signaled() {
+		return fmt.Sprintf("killed by signal %s", ws.Signal())
+	}
+	return fmt.Sprintf("exited with status %d", ws.ExitStatus())
+}
@@ -0,0 +1,23 @@
+// +build !linux
+
+package main
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// syscallTracer is not supported on this platform.
+type syscallTracer struct{}
+
+func newSyscallTracer(record func(audit uint32, nr uint64, args [6]uint64)) *syscallTracer {
+	return &syscallTracer{}
+}
+
+func (t *syscallTracer) start(cmd *exec.Cmd) error {
+	return errors.New("tracing syscalls is only supported on Linux")
+}
+
+func (t *syscallTracer) wait() (string, error) {
+	return "", errors.New("tracing syscalls is only supported on Linux")
+}